@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// CratesIOURL is the base URL for the crates.io API
+	CratesIOURL = "https://crates.io/api/v1/crates"
+)
+
+// cargoDependencySections maps Cargo.toml dependency tables to the suffix used in results
+var cargoDependencySections = map[string]string{
+	"dependencies":       "",
+	"dev-dependencies":   "dev",
+	"build-dependencies": "build",
+}
+
+// CargoHandler handles Rust crate version checking
+type CargoHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+}
+
+// NewCargoHandler creates a new Cargo handler
+func NewCargoHandler(logger *logrus.Logger, cache *sync.Map) *CargoHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &CargoHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// CratesIOCrateInfo represents information about a crate from the crates.io API
+type CratesIOCrateInfo struct {
+	Crate struct {
+		Name             string `json:"name"`
+		MaxVersion       string `json:"max_version"`
+		MaxStableVersion string `json:"max_stable_version"`
+	} `json:"crate"`
+	Versions []struct {
+		Num    string `json:"num"`
+		Yanked bool   `json:"yanked"`
+	} `json:"versions"`
+}
+
+// getCrateInfo gets information about a crate
+func (h *CargoHandler) getCrateInfo(crateName string) (*CratesIOCrateInfo, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("cargo:%s", crateName)
+	if cachedInfo, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("crate", crateName).Debug("Using cached crate info")
+		return cachedInfo.(*CratesIOCrateInfo), nil
+	}
+
+	// Construct URL
+	crateURL := fmt.Sprintf("%s/%s", CratesIOURL, url.PathEscape(crateName))
+	h.logger.WithFields(logrus.Fields{
+		"crate": crateName,
+		"url":   crateURL,
+	}).Debug("Fetching crate info")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", crateURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch crate info: %w", err)
+	}
+
+	// Parse response
+	var info CratesIOCrateInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse crate info: %w", err)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, &info)
+
+	return &info, nil
+}
+
+// parseCargoDependency converts a Cargo.toml dependency entry into a CargoDependency.
+// Entries may be a plain version string or a table such as { version = "1", features = [...] }.
+func parseCargoDependency(name string, raw interface{}) CargoDependency {
+	dep := CargoDependency{Name: name}
+
+	switch value := raw.(type) {
+	case string:
+		dep.Version = value
+	case map[string]interface{}:
+		if version, ok := value["version"].(string); ok {
+			dep.Version = version
+		}
+		if pkg, ok := value["package"].(string); ok {
+			dep.Package = pkg
+		}
+		if features, ok := value["features"].([]interface{}); ok {
+			for _, feature := range features {
+				if featureStr, ok := feature.(string); ok {
+					dep.Features = append(dep.Features, featureStr)
+				}
+			}
+		}
+		if optional, ok := value["optional"].(bool); ok {
+			dep.Optional = optional
+		}
+		if path, ok := value["path"].(string); ok {
+			dep.Path = path
+		}
+		if git, ok := value["git"].(string); ok {
+			dep.Git = git
+		}
+		if workspace, ok := value["workspace"].(bool); ok {
+			dep.Workspace = workspace
+		}
+	default:
+		dep.Version = fmt.Sprintf("%v", value)
+	}
+
+	return dep
+}
+
+// GetLatestVersion gets the latest version of Rust crates from Cargo.toml
+func (h *CargoHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest Cargo crate versions")
+
+	// Parse dependencies
+	depsRaw, ok := args["dependencies"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: dependencies")
+	}
+
+	depsMap, ok := depsRaw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid dependencies format: expected object")
+	}
+
+	// Accept either an object of Cargo.toml tables or a single flat dependency table
+	sections := make(map[string]map[string]interface{})
+	for section := range cargoDependencySections {
+		if sectionDeps, ok := depsMap[section].(map[string]interface{}); ok {
+			sections[section] = sectionDeps
+		}
+	}
+	if len(sections) == 0 {
+		sections["dependencies"] = depsMap
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	// Process each dependency
+	results := make([]PackageVersion, 0)
+	for section, sectionDeps := range sections {
+		for name, raw := range sectionDeps {
+			result := h.processCrate(parseCargoDependency(name, raw), constraints)
+			if suffix := cargoDependencySections[section]; suffix != "" {
+				result.Name = fmt.Sprintf("%s (%s)", result.Name, suffix)
+			}
+			results = append(results, result)
+		}
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// processCrate processes a single Cargo dependency
+func (h *CargoHandler) processCrate(dep CargoDependency, constraints VersionConstraints) PackageVersion {
+	h.logger.WithFields(logrus.Fields{
+		"crate":   dep.Name,
+		"version": dep.Version,
+	}).Debug("Processing Cargo crate")
+
+	// Check if crate should be excluded
+	if constraint, ok := constraints[dep.Name]; ok && constraint.ExcludePackage {
+		return PackageVersion{
+			Name:       dep.Name,
+			Skipped:    true,
+			SkipReason: "Package excluded by constraints",
+		}
+	}
+
+	// Non-registry dependencies can't be checked against crates.io
+	switch {
+	case dep.Workspace:
+		return PackageVersion{
+			Name:       dep.Name,
+			Registry:   "crates.io",
+			Skipped:    true,
+			SkipReason: "Version is inherited from the workspace",
+		}
+	case dep.Path != "":
+		return PackageVersion{
+			Name:       dep.Name,
+			Registry:   "crates.io",
+			Skipped:    true,
+			SkipReason: fmt.Sprintf("Path dependency: %s", dep.Path),
+		}
+	case dep.Git != "":
+		return PackageVersion{
+			Name:       dep.Name,
+			Registry:   "crates.io",
+			Skipped:    true,
+			SkipReason: fmt.Sprintf("Git dependency: %s", dep.Git),
+		}
+	}
+
+	// Renamed dependencies are published under the package name
+	crateName := dep.Name
+	if dep.Package != "" {
+		crateName = dep.Package
+	}
+
+	// Clean version string
+	currentVersion := cleanVersionRequirement(dep.Version)
+
+	// Get crate info
+	info, err := h.getCrateInfo(crateName)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"crate": crateName,
+			"error": err.Error(),
+		}).Error("Failed to get crate info")
+		return PackageVersion{
+			Name:           dep.Name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "crates.io",
+			Skipped:        true,
+			SkipReason:     fmt.Sprintf("Failed to fetch crate info: %v", err),
+		}
+	}
+
+	// Collect non-yanked versions
+	versions := make([]string, 0, len(info.Versions))
+	for _, version := range info.Versions {
+		if !version.Yanked {
+			versions = append(versions, version.Num)
+		}
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[dep.Name]; ok {
+		majorVersion = constraint.MajorVersion
+	}
+
+	latestVersion := FindLatestVersion(versions, majorVersion)
+	if latestVersion == "" {
+		return PackageVersion{
+			Name:           dep.Name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "crates.io",
+			Skipped:        true,
+			SkipReason:     "No stable, non-yanked versions found",
+		}
+	}
+
+	return PackageVersion{
+		Name:           dep.Name,
+		CurrentVersion: StringPtr(currentVersion),
+		LatestVersion:  latestVersion,
+		Registry:       "crates.io",
+	}
+}
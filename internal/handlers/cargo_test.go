@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCargoHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Mock crates.io responses
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("crates.io/api/v1/crates/serde", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"crate": {"name": "serde", "max_stable_version": "1.0.210"}, "versions": [
+			{"num": "2.0.0-alpha.1", "yanked": false},
+			{"num": "1.0.211", "yanked": true},
+			{"num": "1.0.210", "yanked": false},
+			{"num": "0.9.15", "yanked": false}
+		]}`,
+	})
+	mockClient.AddMockResponse("crates.io/api/v1/crates/rand", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"crate": {"name": "rand"}, "versions": [
+			{"num": "0.9.0", "yanked": false},
+			{"num": "0.8.5", "yanked": false}
+		]}`,
+	})
+
+	handler := NewCargoHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	args := map[string]interface{}{
+		"dependencies": map[string]interface{}{
+			"dependencies": map[string]interface{}{
+				"serde": map[string]interface{}{
+					"version":  "1.0",
+					"features": []interface{}{"derive"},
+				},
+				"my-local": map[string]interface{}{
+					"path": "../my-local",
+				},
+			},
+			"dev-dependencies": map[string]interface{}{
+				"rand": "0.8",
+			},
+		},
+		"constraints": map[string]interface{}{
+			"rand": map[string]interface{}{
+				"majorVersion": float64(0),
+			},
+		},
+	}
+
+	result, err := handler.GetLatestVersion(context.Background(), args)
+	require.NoError(t, err)
+	validateToolResult(t, result)
+
+	var versions []PackageVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 3)
+
+	assert.Equal(t, "my-local", versions[0].Name)
+	assert.True(t, versions[0].Skipped)
+	assert.Contains(t, versions[0].SkipReason, "Path dependency")
+
+	assert.Equal(t, "rand (dev)", versions[1].Name)
+	assert.Equal(t, "0.9.0", versions[1].LatestVersion)
+
+	assert.Equal(t, "serde", versions[2].Name)
+	assert.Equal(t, "1.0", *versions[2].CurrentVersion)
+	assert.Equal(t, "1.0.210", versions[2].LatestVersion)
+	assert.Equal(t, "crates.io", versions[2].Registry)
+}
+
+// Helper function to decode the JSON text content of a tool result
+func unmarshalToolResult(t *testing.T, result *mcp.CallToolResult, v interface{}) {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+
+	textContent, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok, "First content item should be text content")
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), v))
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// kubernetesContainerKeys lists the pod spec fields holding container definitions
+var kubernetesContainerKeys = map[string]bool{
+	"containers":          true,
+	"initContainers":      true,
+	"ephemeralContainers": true,
+}
+
+// ContainerImagesHandler handles checking image references in compose files and Kubernetes manifests
+type ContainerImagesHandler struct {
+	logger *logrus.Logger
+	docker *DockerHandler
+}
+
+// NewContainerImagesHandler creates a new container images handler
+func NewContainerImagesHandler(logger *logrus.Logger, cache *sync.Map) *ContainerImagesHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &ContainerImagesHandler{
+		logger: logger,
+		docker: NewDockerHandler(logger, cache),
+	}
+}
+
+// ContainerImageReference represents an image reference found in a YAML document
+type ContainerImageReference struct {
+	Image    string
+	Resource string
+	Location string
+	Line     int
+}
+
+// mappingValue returns the value node for a key of a YAML mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// kubernetesResourceName returns the kind/name of a Kubernetes document such as Deployment/web
+func kubernetesResourceName(document *yaml.Node) string {
+	kind := mappingValue(document, "kind")
+	if kind == nil {
+		return ""
+	}
+	name := ""
+	if nameNode := mappingValue(mappingValue(document, "metadata"), "name"); nameNode != nil {
+		name = nameNode.Value
+	}
+	return fmt.Sprintf("%s/%s", kind.Value, name)
+}
+
+// findComposeImages finds the images of the services in a compose file document
+func findComposeImages(document *yaml.Node) []ContainerImageReference {
+	services := mappingValue(document, "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var references []ContainerImageReference
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		image := mappingValue(services.Content[i+1], "image")
+		if image == nil || image.Kind != yaml.ScalarNode || image.Value == "" {
+			continue
+		}
+		references = append(references, ContainerImageReference{
+			Image:    image.Value,
+			Location: fmt.Sprintf("services.%s.image", services.Content[i].Value),
+			Line:     image.Line,
+		})
+	}
+
+	return references
+}
+
+// findKubernetesImages walks a Kubernetes document and finds the images of every container,
+// init container and ephemeral container, wherever the pod spec is nested
+func findKubernetesImages(node *yaml.Node, path string, references []ContainerImageReference) []ContainerImageReference {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+
+			if kubernetesContainerKeys[key] && value.Kind == yaml.SequenceNode {
+				for index, container := range value.Content {
+					image := mappingValue(container, "image")
+					if image == nil || image.Kind != yaml.ScalarNode || image.Value == "" {
+						continue
+					}
+					references = append(references, ContainerImageReference{
+						Image:    image.Value,
+						Location: fmt.Sprintf("%s[%d].image", keyPath, index),
+						Line:     image.Line,
+					})
+				}
+				continue
+			}
+
+			references = findKubernetesImages(value, keyPath, references)
+		}
+	case yaml.SequenceNode:
+		for index, item := range node.Content {
+			references = findKubernetesImages(item, fmt.Sprintf("%s[%d]", path, index), references)
+		}
+	}
+
+	return references
+}
+
+// parseContainerImageReferences finds the image references in every document of a compose
+// file or Kubernetes manifest
+func parseContainerImageReferences(content string) ([]ContainerImageReference, error) {
+	var references []ContainerImageReference
+
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	for {
+		var root yaml.Node
+		if err := decoder.Decode(&root); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(root.Content) == 0 {
+			continue
+		}
+		document := root.Content[0]
+
+		if mappingValue(document, "services") != nil {
+			references = append(references, findComposeImages(document)...)
+			continue
+		}
+
+		resource := kubernetesResourceName(document)
+		for _, reference := range findKubernetesImages(document, "", nil) {
+			reference.Resource = resource
+			references = append(references, reference)
+		}
+	}
+
+	return references, nil
+}
+
+// GetLatestVersion checks the image references of compose files and Kubernetes manifests
+func (h *ContainerImagesHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Checking container image references")
+
+	// Parse files
+	filesRaw, ok := args["files"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: files")
+	}
+
+	filesArr, ok := filesRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid files format: expected array")
+	}
+
+	// Process each file in order
+	results := make([]DockerImageUpdate, 0)
+	for index, fileRaw := range filesArr {
+		fileMap, ok := fileRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid file format: expected object with path and content")
+		}
+		path, _ := fileMap["path"].(string)
+		if path == "" {
+			path = fmt.Sprintf("file %d", index+1)
+		}
+		content, ok := fileMap["content"].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing required parameter: content for %s", path)
+		}
+
+		references, err := parseContainerImageReferences(content)
+		if err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"file":  path,
+				"error": err.Error(),
+			}).Error("Failed to parse YAML")
+			results = append(results, DockerImageUpdate{
+				PackageVersion: PackageVersion{
+					Name:       path,
+					Skipped:    true,
+					SkipReason: fmt.Sprintf("Failed to parse YAML: %v", err),
+				},
+				File: path,
+			})
+			continue
+		}
+
+		for _, reference := range references {
+			results = append(results, h.processReference(path, reference))
+		}
+	}
+
+	return NewToolResultJSON(results)
+}
+
+// processReference checks a single image reference
+func (h *ContainerImagesHandler) processReference(path string, reference ContainerImageReference) DockerImageUpdate {
+	h.logger.WithFields(logrus.Fields{
+		"image":    reference.Image,
+		"file":     path,
+		"location": reference.Location,
+	}).Debug("Processing container image reference")
+
+	// Compose files may use ${VARIABLE:-default} interpolation
+	result := h.docker.checkImageReference(expandDockerfileVariables(reference.Image, nil), "")
+	result.Reference = reference.Image
+	result.File = path
+	result.Resource = reference.Resource
+	result.Location = reference.Location
+	result.Line = reference.Line
+
+	return result
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testComposeFile = `services:
+  web:
+    image: nginx:1.25-alpine
+  db:
+    image: ${POSTGRES_IMAGE:-postgres:16}
+  app:
+    build: .
+`
+
+const testKubernetesManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          image: postgres:16
+      containers:
+        - name: web
+          image: nginx:1.25-alpine
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: backup
+              image: quay.io/team/backup
+`
+
+func TestParseContainerImageReferences(t *testing.T) {
+	references, err := parseContainerImageReferences(testComposeFile)
+	require.NoError(t, err)
+	assert.Equal(t, []ContainerImageReference{
+		{Image: "nginx:1.25-alpine", Location: "services.web.image", Line: 3},
+		{Image: "${POSTGRES_IMAGE:-postgres:16}", Location: "services.db.image", Line: 5},
+	}, references)
+
+	references, err = parseContainerImageReferences(testKubernetesManifest)
+	require.NoError(t, err)
+	assert.Equal(t, []ContainerImageReference{
+		{Image: "postgres:16", Resource: "Deployment/web", Location: "spec.template.spec.initContainers[0].image", Line: 10},
+		{Image: "nginx:1.25-alpine", Resource: "Deployment/web", Location: "spec.template.spec.containers[0].image", Line: 13},
+		{Image: "quay.io/team/backup", Resource: "CronJob/backup", Location: "spec.jobTemplate.spec.template.spec.containers[0].image", Line: 26},
+	}, references)
+
+	_, err = parseContainerImageReferences("services: [")
+	assert.Error(t, err)
+}
+
+func TestContainerImagesHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/repositories/library/nginx/tags":
+			_, _ = w.Write([]byte(`{"next": null, "results": [
+				{"name": "1.27-alpine", "last_updated": "2025-03-01T00:00:00Z"},
+				{"name": "1.27.4-alpine", "last_updated": "2025-03-01T00:00:00Z"},
+				{"name": "1.27", "last_updated": "2025-03-01T00:00:00Z"}
+			]}`))
+		case "/v2/repositories/library/postgres/tags":
+			_, _ = w.Write([]byte(`{"next": null, "results": [
+				{"name": "17", "last_updated": "2025-03-01T00:00:00Z"},
+				{"name": "16", "last_updated": "2025-03-01T00:00:00Z"}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	handler := NewContainerImagesHandler(logger, &sync.Map{})
+	handler.docker.client = newRedirectClient(server)
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"files": []interface{}{
+			map[string]interface{}{"path": "docker-compose.yml", "content": testComposeFile},
+			map[string]interface{}{"path": "broken.yaml", "content": "services: ["},
+		},
+	})
+	require.NoError(t, err)
+
+	var updates []DockerImageUpdate
+	unmarshalToolResult(t, result, &updates)
+	require.Len(t, updates, 3)
+
+	assert.Equal(t, "nginx", updates[0].Name)
+	require.NotNil(t, updates[0].CurrentVersion)
+	assert.Equal(t, "1.25-alpine", *updates[0].CurrentVersion)
+	assert.Equal(t, "1.27-alpine", updates[0].LatestVersion)
+	assert.True(t, updates[0].UpdateAvailable)
+	assert.Equal(t, "docker-compose.yml", updates[0].File)
+	assert.Equal(t, "services.web.image", updates[0].Location)
+	assert.Equal(t, 3, updates[0].Line)
+
+	// Interpolated images use their default value
+	assert.Equal(t, "postgres", updates[1].Name)
+	assert.Equal(t, "17", updates[1].LatestVersion)
+	assert.Equal(t, "${POSTGRES_IMAGE:-postgres:16}", updates[1].Reference)
+
+	assert.True(t, updates[2].Skipped)
+	assert.Equal(t, "broken.yaml", updates[2].File)
+
+	_, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{})
+	assert.EqualError(t, err, "missing required parameter: files")
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// PubDevURL is the base URL for the pub.dev package repository
+	PubDevURL = "https://pub.dev"
+)
+
+// pubspecDependencySections maps pubspec.yaml dependency maps to the suffix used in results
+var pubspecDependencySections = map[string]string{
+	"dependencies":         "",
+	"dev_dependencies":     "dev",
+	"dependency_overrides": "override",
+}
+
+// DartHandler handles Dart/Flutter package version checking
+type DartHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+}
+
+// NewDartHandler creates a new Dart handler
+func NewDartHandler(logger *logrus.Logger, cache *sync.Map) *DartHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &DartHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// PubPackageInfo represents information about a package from the pub repository API
+type PubPackageInfo struct {
+	Name   string `json:"name"`
+	Latest struct {
+		Version string `json:"version"`
+	} `json:"latest"`
+	Versions []struct {
+		Version   string `json:"version"`
+		Retracted bool   `json:"retracted"`
+	} `json:"versions"`
+	IsDiscontinued bool   `json:"isDiscontinued"`
+	ReplacedBy     string `json:"replacedBy"`
+}
+
+// getPackageInfo gets information about a package from a pub repository
+func (h *DartHandler) getPackageInfo(hostedURL, packageName string) (*PubPackageInfo, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("pub:%s:%s", hostedURL, packageName)
+	if cachedInfo, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("package", packageName).Debug("Using cached pub package info")
+		return cachedInfo.(*PubPackageInfo), nil
+	}
+
+	// Construct URL
+	packageURL := fmt.Sprintf("%s/api/packages/%s", strings.TrimSuffix(hostedURL, "/"), url.PathEscape(packageName))
+	h.logger.WithFields(logrus.Fields{
+		"package": packageName,
+		"url":     packageURL,
+	}).Debug("Fetching pub package info")
+
+	// Make request
+	headers := map[string]string{
+		"Accept": "application/vnd.pub.v2+json",
+	}
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", packageURL, headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pub package info: %w", err)
+	}
+
+	// Parse response
+	var info PubPackageInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse pub package info: %w", err)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, &info)
+
+	return &info, nil
+}
+
+// parsePubspecDependency converts a pubspec.yaml dependency entry into a PubspecDependency.
+// Entries may be a version constraint, null (any version) or a map describing the source.
+func parsePubspecDependency(name string, raw interface{}) PubspecDependency {
+	dep := PubspecDependency{Name: name}
+
+	switch value := raw.(type) {
+	case nil:
+		// No constraint means any version from pub.dev
+	case string:
+		dep.Version = value
+	case map[string]interface{}:
+		if version, ok := value["version"].(string); ok {
+			dep.Version = version
+		}
+		switch hosted := value["hosted"].(type) {
+		case string:
+			dep.HostedURL = hosted
+		case map[string]interface{}:
+			if hostedURL, ok := hosted["url"].(string); ok {
+				dep.HostedURL = hostedURL
+			}
+			if hostedName, ok := hosted["name"].(string); ok {
+				dep.Name = hostedName
+			}
+		}
+		switch {
+		case value["sdk"] != nil:
+			dep.Source = "sdk"
+			dep.SourceDetail = fmt.Sprintf("%v", value["sdk"])
+		case value["git"] != nil:
+			dep.Source = "git"
+			if git, ok := value["git"].(map[string]interface{}); ok {
+				dep.SourceDetail = fmt.Sprintf("%v", git["url"])
+			} else {
+				dep.SourceDetail = fmt.Sprintf("%v", value["git"])
+			}
+		case value["path"] != nil:
+			dep.Source = "path"
+			dep.SourceDetail = fmt.Sprintf("%v", value["path"])
+		}
+	default:
+		dep.Version = fmt.Sprintf("%v", value)
+	}
+
+	if dep.Source == "" {
+		dep.Source = "hosted"
+	}
+
+	return dep
+}
+
+// GetLatestVersion gets the latest version of Dart/Flutter packages from pubspec.yaml
+func (h *DartHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest pub package versions")
+
+	// Parse dependencies
+	depsRaw, ok := args["dependencies"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: dependencies")
+	}
+
+	depsMap, ok := depsRaw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid dependencies format: expected object")
+	}
+
+	// Accept either an object of pubspec.yaml maps or a single flat dependency map
+	sections := make(map[string]map[string]interface{})
+	for section := range pubspecDependencySections {
+		if sectionDeps, ok := depsMap[section].(map[string]interface{}); ok {
+			sections[section] = sectionDeps
+		}
+	}
+	if len(sections) == 0 {
+		sections["dependencies"] = depsMap
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	// Process each dependency
+	results := make([]PubPackageVersion, 0)
+	for section, sectionDeps := range sections {
+		for name, raw := range sectionDeps {
+			result := h.processPackage(name, parsePubspecDependency(name, raw), constraints)
+			if suffix := pubspecDependencySections[section]; suffix != "" {
+				result.Name = fmt.Sprintf("%s (%s)", result.Name, suffix)
+			}
+			results = append(results, result)
+		}
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// processPackage processes a single pubspec.yaml dependency
+func (h *DartHandler) processPackage(name string, dep PubspecDependency, constraints VersionConstraints) PubPackageVersion {
+	h.logger.WithFields(logrus.Fields{
+		"package": name,
+		"version": dep.Version,
+		"source":  dep.Source,
+	}).Debug("Processing pub package")
+
+	// Check if package should be excluded
+	if constraint, ok := constraints[name]; ok && constraint.ExcludePackage {
+		return PubPackageVersion{
+			PackageVersion: PackageVersion{
+				Name:       name,
+				Skipped:    true,
+				SkipReason: "Package excluded by constraints",
+			},
+		}
+	}
+
+	// Only hosted dependencies have published versions
+	if dep.Source != "hosted" {
+		return PubPackageVersion{
+			PackageVersion: PackageVersion{
+				Name:       name,
+				Registry:   "pub",
+				Skipped:    true,
+				SkipReason: fmt.Sprintf("Non-hosted dependency (%s: %s)", dep.Source, dep.SourceDetail),
+			},
+		}
+	}
+
+	hostedURL := PubDevURL
+	if dep.HostedURL != "" {
+		hostedURL = dep.HostedURL
+	}
+
+	// Clean version string, using the lower bound of ranges such as ">=1.0.0 <2.0.0"
+	var currentVersion string
+	if fields := strings.Fields(dep.Version); len(fields) > 0 {
+		currentVersion = cleanVersionRequirement(fields[0])
+	}
+
+	// Get package info
+	info, err := h.getPackageInfo(hostedURL, dep.Name)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"package": dep.Name,
+			"error":   err.Error(),
+		}).Error("Failed to get pub package info")
+		return PubPackageVersion{
+			PackageVersion: PackageVersion{
+				Name:           name,
+				CurrentVersion: StringPtr(currentVersion),
+				LatestVersion:  "unknown",
+				Registry:       "pub",
+				Skipped:        true,
+				SkipReason:     fmt.Sprintf("Failed to fetch package info: %v", err),
+			},
+		}
+	}
+
+	// Retracted versions should never be recommended
+	versions := make([]string, 0, len(info.Versions))
+	for _, version := range info.Versions {
+		if !version.Retracted {
+			versions = append(versions, version.Version)
+		}
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[name]; ok {
+		majorVersion = constraint.MajorVersion
+	}
+
+	result := PubPackageVersion{
+		PackageVersion: PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  FindLatestVersion(versions, majorVersion),
+			Registry:       "pub",
+		},
+		Discontinued: info.IsDiscontinued,
+	}
+	if info.ReplacedBy != "" {
+		result.ReplacedBy = StringPtr(info.ReplacedBy)
+	}
+	if result.LatestVersion == "" {
+		result.LatestVersion = "unknown"
+		result.Skipped = true
+		result.SkipReason = "No matching versions found"
+	}
+
+	return result
+}
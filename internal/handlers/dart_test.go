@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDartHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Mock pub.dev responses with a retracted release and a discontinued package
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("pub.dev/api/packages/http", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"name": "http", "latest": {"version": "1.2.2"}, "versions": [
+			{"version": "1.1.0"},
+			{"version": "1.2.1"},
+			{"version": "1.2.2", "retracted": true},
+			{"version": "2.0.0-dev.1"}
+		]}`,
+	})
+	mockClient.AddMockResponse("pub.dev/api/packages/pedantic", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"name": "pedantic", "latest": {"version": "1.11.1"}, "versions": [
+			{"version": "1.11.1"}
+		], "isDiscontinued": true, "replacedBy": "lints"}`,
+	})
+	mockClient.AddMockResponse("pub.dev/api/packages/retracted_only", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `{"name": "retracted_only", "latest": {"version": "1.0.0"}, "versions": [{"version": "1.0.0", "retracted": true}]}`,
+	})
+
+	handler := NewDartHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": map[string]interface{}{
+			"dependencies": map[string]interface{}{
+				"http":           "^1.1.0",
+				"retracted_only": "^1.0.0",
+				"flutter":        map[string]interface{}{"sdk": "flutter"},
+				"shared":         map[string]interface{}{"path": "../shared"},
+				"forked": map[string]interface{}{
+					"git": map[string]interface{}{"url": "https://github.com/example/forked.git"},
+				},
+			},
+			"dev_dependencies": map[string]interface{}{
+				"pedantic": "^1.9.0",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var versions []PubPackageVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 6)
+
+	// Non-hosted dependencies are skipped
+	assert.Equal(t, "flutter", versions[0].Name)
+	assert.True(t, versions[0].Skipped)
+	assert.Contains(t, versions[0].SkipReason, "sdk: flutter")
+	assert.Equal(t, "forked", versions[1].Name)
+	assert.True(t, versions[1].Skipped)
+	assert.Contains(t, versions[1].SkipReason, "git: https://github.com/example/forked.git")
+
+	// Retracted versions are ignored
+	assert.Equal(t, "http", versions[2].Name)
+	assert.Equal(t, "1.1.0", *versions[2].CurrentVersion)
+	assert.Equal(t, "1.2.1", versions[2].LatestVersion)
+	assert.False(t, versions[2].Skipped)
+
+	// Discontinued packages report their replacement
+	assert.Equal(t, "pedantic (dev)", versions[3].Name)
+	assert.Equal(t, "1.11.1", versions[3].LatestVersion)
+	assert.True(t, versions[3].Discontinued)
+	require.NotNil(t, versions[3].ReplacedBy)
+	assert.Equal(t, "lints", *versions[3].ReplacedBy)
+
+	assert.Equal(t, "retracted_only", versions[4].Name)
+	assert.Equal(t, "unknown", versions[4].LatestVersion)
+	assert.True(t, versions[4].Skipped)
+	assert.Equal(t, "No matching versions found", versions[4].SkipReason)
+
+	assert.Equal(t, "shared", versions[5].Name)
+	assert.True(t, versions[5].Skipped)
+	assert.Contains(t, versions[5].SkipReason, "path: ../shared")
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,11 +16,36 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// GHCRHost is the host of the GitHub Container Registry
+	GHCRHost = "ghcr.io"
+	// GARHostSuffix is the host suffix of Google Artifact Registry Docker repositories
+	GARHostSuffix = "-docker.pkg.dev"
+	// DockerHubDefaultMaxPages is the default number of Docker Hub tag pages followed per image
+	DockerHubDefaultMaxPages = 10
+)
+
+// namedRegistryHosts maps the named OCI registries to their hosts
+var namedRegistryHosts = map[string]string{
+	"quay":       "quay.io",
+	"ecr-public": "public.ecr.aws",
+	"mcr":        "mcr.microsoft.com",
+}
+
+var (
+	// dockerTagVersionRegex matches version tags such as 1.25, v3.19.1 or 20-alpine3.19
+	dockerTagVersionRegex = regexp.MustCompile(`^[vV]?(\d+(?:\.\d+)*)(?:-(.+))?$`)
+	// dockerTagPrereleaseRegex matches tag suffixes that denote a pre-release rather than a variant
+	dockerTagPrereleaseRegex = regexp.MustCompile(`(?i)^(alpha|beta|rc|pre|preview|dev|snapshot|nightly|canary)[.\d]*(-|$)`)
+)
+
 // DockerHandler handles Docker image version checking
 type DockerHandler struct {
 	client HTTPClient
 	cache  *sync.Map
 	logger *logrus.Logger
+	// credentials looks up registry credentials by host, returning nil for anonymous access
+	credentials func(host string) *RegistryCredentials
 }
 
 // NewDockerHandler creates a new Docker handler
@@ -26,9 +54,10 @@ func NewDockerHandler(logger *logrus.Logger, cache *sync.Map) *DockerHandler {
 		cache = &sync.Map{}
 	}
 	return &DockerHandler{
-		client: DefaultHTTPClient,
-		cache:  cache,
-		logger: logger,
+		client:      DefaultHTTPClient,
+		cache:       cache,
+		logger:      logger,
+		credentials: lookupDockerConfigCredentials,
 	}
 }
 
@@ -39,22 +68,19 @@ type DockerHubTagsResponse struct {
 	Previous string `json:"previous"`
 	Results  []struct {
 		Name        string    `json:"name"`
+		Digest      string    `json:"digest"`
 		FullSize    int64     `json:"full_size"`
 		LastUpdated time.Time `json:"last_updated"`
 		Images      []struct {
 			Digest       string `json:"digest"`
 			Architecture string `json:"architecture"`
 			OS           string `json:"os"`
+			Variant      string `json:"variant"`
 			Size         int64  `json:"size"`
 		} `json:"images"`
 	} `json:"results"`
 }
 
-// GHCRTagsResponse represents a response from the GitHub Container Registry API
-type GHCRTagsResponse struct {
-	Tags []string `json:"tags"`
-}
-
 // GetLatestVersion gets information about Docker image tags
 func (h *DockerHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	h.logger.Debug("Getting Docker image tag information")
@@ -65,21 +91,34 @@ func (h *DockerHandler) GetLatestVersion(ctx context.Context, args map[string]in
 		return nil, fmt.Errorf("missing required parameter: image")
 	}
 
-	// Parse registry
-	registry := "dockerhub"
-	if registryRaw, ok := args["registry"].(string); ok && registryRaw != "" {
-		registry = registryRaw
-	}
-
 	// Parse custom registry
 	customRegistry := ""
 	if customRegistryRaw, ok := args["customRegistry"].(string); ok {
 		customRegistry = customRegistryRaw
 	}
 
+	// Parse registry, detecting it from fully qualified image references when not given
+	registry := ""
+	if registryRaw, ok := args["registry"].(string); ok && registryRaw != "" {
+		registry = registryRaw
+	} else {
+		var host string
+		registry, host = detectRegistry(image)
+		if registry == "custom" && customRegistry == "" {
+			customRegistry = host
+		}
+		h.logger.WithFields(logrus.Fields{
+			"image":    image,
+			"registry": registry,
+		}).Debug("Detected Docker registry from image reference")
+	}
+
 	// Parse limit
 	limit := 10
 	if limitRaw, ok := args["limit"].(float64); ok {
+		if limitRaw < 1 {
+			return nil, fmt.Errorf("invalid limit: %v (must be at least 1)", limitRaw)
+		}
 		limit = int(limitRaw)
 	}
 
@@ -99,37 +138,201 @@ func (h *DockerHandler) GetLatestVersion(ctx context.Context, args map[string]in
 		includeDigest = includeDigestRaw
 	}
 
+	// Parse sort order
+	sortOrder := ""
+	if sortRaw, ok := args["sort"].(string); ok {
+		sortOrder = sortRaw
+	}
+	switch sortOrder {
+	case "", "semver", "updated":
+	default:
+		return nil, fmt.Errorf("invalid sort: %s", sortOrder)
+	}
+
+	// Parse max pages
+	maxPages := DockerHubDefaultMaxPages
+	if maxPagesRaw, ok := args["maxPages"].(float64); ok && maxPagesRaw >= 1 {
+		maxPages = int(maxPagesRaw)
+	}
+
+	// Parse platforms
+	includePlatforms := false
+	if platformsRaw, ok := args["platforms"].(bool); ok {
+		includePlatforms = platformsRaw
+	}
+	platform := ""
+	if platformRaw, ok := args["platform"].(string); ok && platformRaw != "" {
+		if _, _, _, err := parsePlatform(platformRaw); err != nil {
+			return nil, err
+		}
+		platform = platformRaw
+	}
+
+	query := DockerImageQuery{
+		Image:          image,
+		Registry:       registry,
+		CustomRegistry: customRegistry,
+		Limit:          limit,
+		FilterTags:     filterTags,
+		IncludeDigest:  includeDigest,
+		Sort:           sortOrder,
+		MaxPages:       maxPages,
+		Platforms:      includePlatforms,
+		Platform:       platform,
+	}
+
+	// Recommend an update for the current tag if one was given
+	if currentTag, ok := args["currentTag"].(string); ok && currentTag != "" {
+		recommendation, err := h.recommendTag(query, currentTag)
+		if err != nil {
+			return nil, err
+		}
+		return NewToolResultJSON(recommendation)
+	}
+
 	// Get tags based on registry
-	var tags []DockerImageVersion
-	var err error
-	switch registry {
+	tags, err := h.getTags(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewToolResultJSON(tags)
+}
+
+// getTags gets tags from the registry selected by the query
+func (h *DockerHandler) getTags(query DockerImageQuery) ([]DockerImageVersion, error) {
+	switch query.Registry {
 	case "dockerhub":
-		tags, err = h.getDockerHubTags(image, limit, filterTags, includeDigest)
+		return h.getDockerHubTags(query)
 	case "ghcr":
-		tags, err = h.getGHCRTags(image, limit, filterTags, includeDigest)
+		return h.getGHCRTags(query)
+	case "quay", "ecr-public", "mcr", "gar":
+		return h.getNamedRegistryTags(query)
 	case "custom":
-		if customRegistry == "" {
+		if query.CustomRegistry == "" {
 			return nil, fmt.Errorf("missing required parameter for custom registry: customRegistry")
 		}
-		tags, err = h.getCustomRegistryTags(image, customRegistry, limit, filterTags, includeDigest)
+		return h.getCustomRegistryTags(query)
 	default:
-		return nil, fmt.Errorf("invalid registry: %s", registry)
+		return nil, fmt.Errorf("invalid registry: %s", query.Registry)
+	}
+}
+
+// registryForHost returns the registry name used by check_docker_tags for a registry host
+func registryForHost(host string) string {
+	switch {
+	case host == "docker.io" || host == "registry-1.docker.io" || host == "index.docker.io":
+		return "dockerhub"
+	case host == GHCRHost:
+		return "ghcr"
+	case strings.HasSuffix(host, GARHostSuffix):
+		return "gar"
+	}
+	for registry, registryHost := range namedRegistryHosts {
+		if host == registryHost {
+			return registry
+		}
+	}
+	return "custom"
+}
+
+// detectRegistry detects the registry of an image reference such as
+// quay.io/prometheus/node-exporter, returning the registry name and host. Images without
+// a registry host (e.g. nginx or grafana/grafana) are on Docker Hub.
+func detectRegistry(image string) (registry, host string) {
+	host, _, found := strings.Cut(image, "/")
+	if !found || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return "dockerhub", ""
+	}
+	return registryForHost(host), host
+}
+
+// recommendTag finds the newest tag with the same variant and precision as the current
+// tag, so that e.g. 3.11-slim-bookworm is compared with 3.13-slim-bookworm but not with
+// 3.13.2-slim-bookworm or 3.13-alpine
+func (h *DockerHandler) recommendTag(query DockerImageQuery, currentTag string) (*DockerTagRecommendation, error) {
+	h.logger.WithFields(logrus.Fields{
+		"image":      query.Image,
+		"currentTag": currentTag,
+	}).Debug("Recommending Docker image tag")
+
+	recommendation := &DockerTagRecommendation{
+		PackageVersion: PackageVersion{
+			Name:           query.Image,
+			CurrentVersion: StringPtr(currentTag),
+			LatestVersion:  "unknown",
+			Registry:       query.Registry,
+		},
 	}
 
+	currentParts, variant, ok := parseDockerTagVersion(currentTag)
+	if !ok {
+		recommendation.Skipped = true
+		recommendation.SkipReason = fmt.Sprintf("Current tag %s is not a version tag", currentTag)
+		return recommendation, nil
+	}
+	recommendation.Variant = variant
+	recommendation.Precision = len(currentParts)
+
+	// Consider every tag, resolving the digest and platforms of the chosen tag afterwards
+	allTagsQuery := query
+	allTagsQuery.Limit = math.MaxInt32
+	allTagsQuery.IncludeDigest = false
+	allTagsQuery.Sort = ""
+	allTagsQuery.Platforms = false
+	allTagsQuery.Platform = ""
+	tags, err := h.getTags(allTagsQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewToolResultJSON(tags)
+	var candidates []dockerTagVersion
+	for _, tag := range tags {
+		parts, tagVariant, ok := parseDockerTagVersion(tag.Tag)
+		if !ok || tagVariant != variant || len(parts) != len(currentParts) {
+			continue
+		}
+		candidates = append(candidates, dockerTagVersion{tag: tag, parts: parts, variant: tagVariant})
+	}
+	newest, latest, err := h.resolveNewestTag(query, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if newest == nil {
+		recommendation.Skipped = true
+		recommendation.SkipReason = fmt.Sprintf("No tags found matching variant %q with %d version components", variant, len(currentParts))
+		if query.Platform != "" {
+			recommendation.SkipReason += fmt.Sprintf(" for platform %s", query.Platform)
+		}
+		return recommendation, nil
+	}
+
+	recommendation.LatestVersion = latest.Tag
+	recommendation.Registry = latest.Registry
+	recommendation.Digest = latest.Digest
+	recommendation.Created = latest.Created
+	recommendation.Platforms = latest.Platforms
+	recommendation.UpdateAvailable = compareDockerTagVersions(newest.parts, currentParts) > 0
+
+	return recommendation, nil
 }
 
-// getDockerHubTags gets tags from Docker Hub
-func (h *DockerHandler) getDockerHubTags(image string, limit int, filterTags []string, includeDigest bool) ([]DockerImageVersion, error) {
+// getDockerHubTags gets tags from Docker Hub, following pagination up to query.MaxPages pages
+func (h *DockerHandler) getDockerHubTags(query DockerImageQuery) ([]DockerImageVersion, error) {
+	image := query.Image
+	if registry, host := detectRegistry(image); registry == "dockerhub" && host != "" {
+		image = strings.TrimPrefix(image, host+"/")
+	}
+	maxPages := query.MaxPages
+	if maxPages < 1 {
+		maxPages = DockerHubDefaultMaxPages
+	}
+
 	// Check cache first
-	cacheKey := fmt.Sprintf("dockerhub:%s", image)
+	cacheKey := fmt.Sprintf("dockerhub:%s:%d", image, maxPages)
 	if cachedTags, ok := h.cache.Load(cacheKey); ok {
 		h.logger.WithField("image", image).Debug("Using cached Docker Hub tags")
-		return h.filterTags(cachedTags.([]DockerImageVersion), limit, filterTags), nil
+		return h.selectTags(cachedTags.([]DockerImageVersion), query), nil
 	}
 
 	// Parse image name
@@ -145,129 +348,415 @@ func (h *DockerHandler) getDockerHubTags(image string, limit int, filterTags []s
 
 	// Construct URL
 	tagsURL := fmt.Sprintf("https://hub.docker.com/v2/repositories/%s/%s/tags?page_size=100", namespace, repo)
-	h.logger.WithFields(logrus.Fields{
-		"image": image,
-		"url":   tagsURL,
-	}).Debug("Fetching Docker Hub tags")
-
-	// Make request
-	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", tagsURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch Docker Hub tags: %w", err)
-	}
-
-	// Parse response
-	var response DockerHubTagsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse Docker Hub tags: %w", err)
-	}
 
-	// Convert to DockerImageVersion
 	var tags []DockerImageVersion
-	for _, result := range response.Results {
-		tag := DockerImageVersion{
-			Name:     image,
-			Tag:      result.Name,
-			Registry: "dockerhub",
+	for page := 0; tagsURL != "" && page < maxPages; page++ {
+		h.logger.WithFields(logrus.Fields{
+			"image": image,
+			"url":   tagsURL,
+			"page":  page + 1,
+		}).Debug("Fetching Docker Hub tags")
+
+		// Make request
+		body, err := MakeRequestWithLogger(h.client, h.logger, "GET", tagsURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Docker Hub tags: %w", err)
 		}
 
-		// Add digest if requested
-		if includeDigest && len(result.Images) > 0 {
-			digest := result.Images[0].Digest
-			tag.Digest = &digest
+		// Parse response
+		var response DockerHubTagsResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse Docker Hub tags: %w", err)
 		}
 
-		// Add created date
-		created := result.LastUpdated.Format(time.RFC3339)
-		tag.Created = &created
+		// Convert to DockerImageVersion
+		for _, result := range response.Results {
+			tag := DockerImageVersion{
+				Name:     image,
+				Tag:      result.Name,
+				Registry: "dockerhub",
+			}
+
+			// Add digest, which is filtered out later unless requested. Multi-arch tags
+			// report the digest of the image index rather than of a single platform.
+			if result.Digest != "" {
+				tag.Digest = StringPtr(result.Digest)
+			} else if len(result.Images) > 0 {
+				digest := result.Images[0].Digest
+				tag.Digest = &digest
+			}
+
+			// Add platforms, skipping attestation manifests
+			for _, image := range result.Images {
+				if image.OS == "" || image.OS == "unknown" {
+					continue
+				}
+				tag.Platforms = append(tag.Platforms, DockerImagePlatform{
+					OS:           image.OS,
+					Architecture: image.Architecture,
+					Variant:      image.Variant,
+					Digest:       image.Digest,
+					Size:         image.Size,
+				})
+			}
 
-		// Add size
-		if len(result.Images) > 0 {
-			size := fmt.Sprintf("%d", result.Images[0].Size)
-			tag.Size = &size
+			// Add created date
+			created := result.LastUpdated.Format(time.RFC3339)
+			tag.Created = &created
+
+			// Add size
+			if len(result.Images) > 0 {
+				size := fmt.Sprintf("%d", result.Images[0].Size)
+				tag.Size = &size
+			}
+
+			tags = append(tags, tag)
 		}
 
-		tags = append(tags, tag)
+		tagsURL = response.Next
 	}
 
 	// Cache result
 	h.cache.Store(cacheKey, tags)
 
-	return h.filterTags(tags, limit, filterTags), nil
+	return h.selectTags(tags, query), nil
 }
 
 // getGHCRTags gets tags from GitHub Container Registry
-func (h *DockerHandler) getGHCRTags(image string, limit int, filterTags []string, includeDigest bool) ([]DockerImageVersion, error) {
+func (h *DockerHandler) getGHCRTags(query DockerImageQuery) ([]DockerImageVersion, error) {
+	// Parse image name, keeping every path segment of nested images (e.g. owner/group/image)
+	repository := strings.ToLower(strings.Trim(strings.TrimPrefix(query.Image, GHCRHost+"/"), "/"))
+	if len(strings.Split(repository, "/")) < 2 {
+		return nil, fmt.Errorf("invalid GHCR image format: %s", query.Image)
+	}
+
+	// Private packages need a GitHub token, public packages use an anonymous token
+	credentials := h.credentials(GHCRHost)
+	if credentials == nil {
+		if token := GitHubTokenFromEnv(); token != "" {
+			credentials = &RegistryCredentials{Username: "token", Password: token}
+		}
+	}
+
+	return h.getOCIRegistryTags(GHCRHost+"/"+repository, GHCRHost, repository, "ghcr", credentials, query)
+}
+
+// getNamedRegistryTags gets tags from Quay.io, Amazon ECR Public, the Microsoft Artifact
+// Registry or Google Artifact Registry through their OCI Distribution endpoints
+func (h *DockerHandler) getNamedRegistryTags(query DockerImageQuery) ([]DockerImageVersion, error) {
+	host := namedRegistryHosts[query.Registry]
+	if query.Registry == "gar" {
+		// Artifact Registry hosts are regional, e.g. us-docker.pkg.dev/project/repository/image
+		host, _, _ = strings.Cut(query.Image, "/")
+		if !strings.HasSuffix(host, GARHostSuffix) {
+			if query.CustomRegistry == "" {
+				return nil, fmt.Errorf("invalid Google Artifact Registry image format: %s (expected LOCATION-docker.pkg.dev/PROJECT/REPOSITORY/IMAGE)", query.Image)
+			}
+			host = registryHost(query.CustomRegistry)
+		}
+	}
+
+	repository := strings.Trim(strings.TrimPrefix(query.Image, host+"/"), "/")
+	if repository == "" {
+		return nil, fmt.Errorf("invalid image format: %s", query.Image)
+	}
+
+	return h.getOCIRegistryTags(host+"/"+repository, host, repository, query.Registry, h.credentials(host), query)
+}
+
+// getCustomRegistryTags gets tags from a registry implementing the OCI Distribution API
+func (h *DockerHandler) getCustomRegistryTags(query DockerImageQuery) ([]DockerImageVersion, error) {
+	host := registryHost(query.CustomRegistry)
+
+	// Allow the image to be given fully qualified with the registry host
+	repository := strings.TrimPrefix(query.Image, host+"/")
+
+	return h.getOCIRegistryTags(repository, query.CustomRegistry, repository, "custom", h.credentials(host), query)
+}
+
+// getOCIRegistryTags lists, filters and optionally resolves digests for the tags of a
+// repository on a registry implementing the OCI Distribution API
+func (h *DockerHandler) getOCIRegistryTags(name, registry, repository, registryLabel string, credentials *RegistryCredentials, query DockerImageQuery) ([]DockerImageVersion, error) {
+	host := registryHost(registry)
+	client := newOCIRegistryClient(h.client, h.logger, registry, repository, credentials)
+
 	// Check cache first
-	cacheKey := fmt.Sprintf("ghcr:%s", image)
+	cacheKey := fmt.Sprintf("oci:%s/%s", host, repository)
+	var tags []DockerImageVersion
 	if cachedTags, ok := h.cache.Load(cacheKey); ok {
-		h.logger.WithField("image", image).Debug("Using cached GHCR tags")
-		return h.filterTags(cachedTags.([]DockerImageVersion), limit, filterTags), nil
+		h.logger.WithFields(logrus.Fields{
+			"image":    repository,
+			"registry": host,
+		}).Debug("Using cached registry tags")
+		tags = cachedTags.([]DockerImageVersion)
+	} else {
+		h.logger.WithFields(logrus.Fields{
+			"image":    repository,
+			"registry": host,
+		}).Debug("Fetching registry tags")
+
+		tagNames, err := client.listTags()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tags from %s: %w", host, err)
+		}
+
+		// Convert to DockerImageVersion
+		for _, tag := range tagNames {
+			tags = append(tags, DockerImageVersion{
+				Name:     name,
+				Tag:      tag,
+				Registry: registryLabel,
+			})
+		}
+
+		// Cache result
+		h.cache.Store(cacheKey, tags)
 	}
 
-	// Parse image name
-	if !strings.HasPrefix(image, "ghcr.io/") {
-		image = "ghcr.io/" + image
+	// Platforms can only be checked by fetching each tag's manifest
+	if query.Platforms || query.Platform != "" {
+		return h.resolveOCIPlatforms(client, repository, tags, query)
 	}
 
-	// Extract owner and repo
-	parts := strings.Split(strings.TrimPrefix(image, "ghcr.io/"), "/")
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("invalid GHCR image format: %s", image)
+	filtered := h.selectTags(tags, query)
+	if !query.IncludeDigest {
+		return filtered, nil
 	}
 
-	owner := parts[0]
-	repo := parts[1]
+	// Resolve digests only for the tags being returned
+	results := make([]DockerImageVersion, 0, len(filtered))
+	for _, tag := range filtered {
+		digest, err := client.manifestDigest(tag.Tag)
+		if err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"image": repository,
+				"tag":   tag.Tag,
+				"error": err.Error(),
+			}).Warn("Failed to resolve manifest digest")
+		} else {
+			tag.Digest = StringPtr(digest)
+		}
+		results = append(results, tag)
+	}
 
-	// Construct URL
-	tagsURL := fmt.Sprintf("https://ghcr.io/v2/%s/%s/tags/list", owner, repo)
-	h.logger.WithFields(logrus.Fields{
-		"image": image,
-		"url":   tagsURL,
-	}).Debug("Fetching GHCR tags")
+	return results, nil
+}
+
+// resolveOCIPlatforms fetches the image index of each selected tag to report its platforms
+// and filter out tags that don't support the requested platform
+func (h *DockerHandler) resolveOCIPlatforms(client *ociRegistryClient, repository string, tags []DockerImageVersion, query DockerImageQuery) ([]DockerImageVersion, error) {
+	candidatesQuery := query
+	candidatesQuery.Limit = math.MaxInt32
+	candidatesQuery.Platform = ""
+	candidates := h.selectTags(tags, candidatesQuery)
+
+	var results []DockerImageVersion
+	for _, tag := range candidates {
+		if len(results) >= query.Limit {
+			break
+		}
+
+		digest, platforms, err := client.manifestPlatforms(tag.Tag)
+		if err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"image": repository,
+				"tag":   tag.Tag,
+				"error": err.Error(),
+			}).Warn("Failed to resolve manifest platforms")
+			continue
+		}
+		if query.Platform != "" && !supportsPlatform(platforms, query.Platform) {
+			continue
+		}
 
-	// Make request
-	headers := map[string]string{
-		"Accept": "application/vnd.github.v3+json",
+		if query.IncludeDigest || query.Platforms {
+			tag.Digest = StringPtr(digest)
+		}
+		if query.Platforms {
+			tag.Platforms = platforms
+		}
+		results = append(results, tag)
+	}
+
+	return results, nil
+}
+
+// parsePlatform splits a platform such as linux/arm64 or linux/arm/v7 into its parts
+func parsePlatform(platform string) (platformOS, architecture, variant string, err error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid platform: %s (expected os/architecture[/variant])", platform)
+	}
+	if len(parts) == 3 {
+		variant = parts[2]
 	}
-	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", tagsURL, headers)
+	return parts[0], parts[1], variant, nil
+}
+
+// supportsPlatform reports whether any of the platforms matches the requested platform.
+// A request without a variant matches every variant of the architecture.
+func supportsPlatform(platforms []DockerImagePlatform, platform string) bool {
+	platformOS, architecture, variant, err := parsePlatform(platform)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch GHCR tags: %w", err)
+		return false
+	}
+	for _, candidate := range platforms {
+		if candidate.OS == platformOS && candidate.Architecture == architecture && (variant == "" || candidate.Variant == variant) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Parse response
-	var response GHCRTagsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse GHCR tags: %w", err)
+// getRegistryTags gets all tags for a repository on the given registry host, dispatching
+// to the registry-specific implementation used by the check_docker_tags tool
+func (h *DockerHandler) getRegistryTags(registryHost, repository string) ([]DockerImageVersion, error) {
+	query := DockerImageQuery{
+		Image:          repository,
+		Registry:       registryForHost(registryHost),
+		CustomRegistry: registryHost,
+		Limit:          math.MaxInt32,
+	}
+	if query.Registry == "gar" {
+		query.Image = registryHost + "/" + repository
 	}
 
-	// Convert to DockerImageVersion
-	var tags []DockerImageVersion
-	for _, tag := range response.Tags {
-		tags = append(tags, DockerImageVersion{
-			Name:     image,
-			Tag:      tag,
-			Registry: "ghcr",
-		})
+	return h.getTags(query)
+}
+
+// selectTags applies the platform and regex filters, sort order and limit of a query to a
+// list of tags
+func (h *DockerHandler) selectTags(tags []DockerImageVersion, query DockerImageQuery) []DockerImageVersion {
+	// Digests and platforms are only returned when requested
+	selected := make([]DockerImageVersion, 0, len(tags))
+	for _, tag := range tags {
+		if query.Platform != "" && !supportsPlatform(tag.Platforms, query.Platform) {
+			continue
+		}
+		if !query.IncludeDigest && !query.Platforms {
+			tag.Digest = nil
+		}
+		if !query.Platforms {
+			tag.Platforms = nil
+		}
+		selected = append(selected, tag)
 	}
+	tags = selected
 
-	// Cache result
-	h.cache.Store(cacheKey, tags)
+	if query.Sort == "" {
+		return h.filterTags(tags, query.Limit, query.FilterTags)
+	}
 
-	return h.filterTags(tags, limit, filterTags), nil
+	sorted := sortTagsByVariant(h.filterTags(tags, math.MaxInt32, query.FilterTags), query.Sort)
+	if len(sorted) > query.Limit {
+		sorted = sorted[:query.Limit]
+	}
+	return sorted
 }
 
-// getCustomRegistryTags gets tags from a custom registry
-func (h *DockerHandler) getCustomRegistryTags(image, registry string, limit int, filterTags []string, includeDigest bool) ([]DockerImageVersion, error) {
-	// This is a placeholder for custom registry implementation
-	// In a real implementation, this would fetch data from the specified registry
-	return []DockerImageVersion{
-		{
-			Name:     image,
-			Tag:      "latest",
-			Registry: registry,
-		},
-	}, nil
+// dockerTagVersion is a tag parsed into its numeric version and variant suffix
+type dockerTagVersion struct {
+	tag      DockerImageVersion
+	parts    []int
+	variant  string
+	modified time.Time
+}
+
+// parseDockerTagVersion parses a tag such as 1.25.3-alpine into its version parts and
+// variant. Tags that aren't versions, or that are pre-releases, are not parsed.
+func parseDockerTagVersion(tag string) (parts []int, variant string, ok bool) {
+	matches := dockerTagVersionRegex.FindStringSubmatch(tag)
+	if matches == nil || dockerTagPrereleaseRegex.MatchString(matches[2]) {
+		return nil, "", false
+	}
+
+	for _, part := range strings.Split(matches[1], ".") {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, "", false
+		}
+		parts = append(parts, number)
+	}
+
+	return parts, matches[2], true
+}
+
+// compareDockerTagVersions compares two parsed versions, treating a more precise tag
+// (1.25.0) as newer than an equal but less precise one (1.25)
+func compareDockerTagVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var partA, partB int
+		if i < len(a) {
+			partA = a[i]
+		}
+		if i < len(b) {
+			partB = b[i]
+		}
+		if partA != partB {
+			if partA > partB {
+				return 1
+			}
+			return -1
+		}
+	}
+	switch {
+	case len(a) > len(b):
+		return 1
+	case len(a) < len(b):
+		return -1
+	default:
+		return 0
+	}
+}
+
+// newerDockerTag reports whether a is newer than b for the given sort order. Registries
+// that don't report update times fall back to comparing versions.
+func newerDockerTag(a, b dockerTagVersion, sortOrder string) bool {
+	if sortOrder == "updated" && !a.modified.Equal(b.modified) {
+		return a.modified.After(b.modified)
+	}
+	if cmp := compareDockerTagVersions(a.parts, b.parts); cmp != 0 {
+		return cmp > 0
+	}
+	return a.tag.Tag < b.tag.Tag
+}
+
+// sortTagsByVariant groups version tags by variant (e.g. alpine or bookworm) and returns
+// the newest tag of each variant, newest first. Tags that aren't versions are dropped.
+func sortTagsByVariant(tags []DockerImageVersion, sortOrder string) []DockerImageVersion {
+	newest := make(map[string]dockerTagVersion)
+	for _, tag := range tags {
+		parts, variant, ok := parseDockerTagVersion(tag.Tag)
+		if !ok {
+			continue
+		}
+		candidate := dockerTagVersion{tag: tag, parts: parts, variant: variant}
+		if tag.Created != nil {
+			if modified, err := time.Parse(time.RFC3339, *tag.Created); err == nil {
+				candidate.modified = modified
+			}
+		}
+		if current, ok := newest[variant]; !ok || newerDockerTag(candidate, current, sortOrder) {
+			newest[variant] = candidate
+		}
+	}
+
+	candidates := make([]dockerTagVersion, 0, len(newest))
+	for _, candidate := range newest {
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return newerDockerTag(candidates[i], candidates[j], sortOrder)
+	})
+
+	results := make([]DockerImageVersion, 0, len(candidates))
+	for _, candidate := range candidates {
+		tag := candidate.tag
+		tag.Variant = StringPtr(candidate.variant)
+		results = append(results, tag)
+	}
+
+	return results
 }
 
 // filterTags filters tags based on regex patterns and limit
@@ -308,3 +797,158 @@ func (h *DockerHandler) filterTags(tags []DockerImageVersion, limit int, filterT
 
 	return filteredTags
 }
+
+// resolveNewestTag returns the newest of the candidate tags, resolving its digest and
+// platforms as requested by the query and skipping tags that don't support the requested
+// platform. Only the tags that are inspected have their manifests fetched.
+func (h *DockerHandler) resolveNewestTag(query DockerImageQuery, candidates []dockerTagVersion) (*dockerTagVersion, DockerImageVersion, error) {
+	sort.Slice(candidates, func(i, j int) bool {
+		return newerDockerTag(candidates[i], candidates[j], "semver")
+	})
+
+	for i := range candidates {
+		if !query.IncludeDigest && !query.Platforms && query.Platform == "" {
+			return &candidates[i], candidates[i].tag, nil
+		}
+
+		detailQuery := query
+		detailQuery.Limit = 1
+		detailQuery.Sort = ""
+		detailQuery.FilterTags = []string{"^" + regexp.QuoteMeta(candidates[i].tag.Tag) + "$"}
+		resolved, err := h.getTags(detailQuery)
+		if err != nil {
+			return nil, DockerImageVersion{}, err
+		}
+		if len(resolved) > 0 {
+			return &candidates[i], resolved[0], nil
+		}
+	}
+
+	return nil, DockerImageVersion{}, nil
+}
+
+// getTagDigest resolves the manifest digest a tag currently points to, for the platform of
+// the query when one is set. A nil digest is returned when the tag doesn't exist.
+func (h *DockerHandler) getTagDigest(query DockerImageQuery, tag string) (*string, error) {
+	tagQuery := query
+	tagQuery.Limit = 1
+	tagQuery.Sort = ""
+	tagQuery.IncludeDigest = true
+	tagQuery.FilterTags = []string{"^" + regexp.QuoteMeta(tag) + "$"}
+	resolved, err := h.getTags(tagQuery)
+	if err != nil || len(resolved) == 0 {
+		return nil, err
+	}
+	return resolved[0].Digest, nil
+}
+
+// parseImageReference splits an image reference such as nginx:1.25@sha256:abc into its
+// image name, tag and digest
+func parseImageReference(reference string) (image, tag, digest string) {
+	image, digest, _ = strings.Cut(reference, "@")
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		image, tag = image[:idx], image[idx+1:]
+	}
+	return image, tag, digest
+}
+
+// checkImageReference finds the newest tag of the same variant and precision for an image
+// reference, resolving digests when the reference is pinned to one
+func (h *DockerHandler) checkImageReference(reference, platform string) DockerImageUpdate {
+	image, tag, digest := parseImageReference(reference)
+	registry, host := detectRegistry(image)
+
+	result := DockerImageUpdate{
+		PackageVersion: PackageVersion{
+			Name:          image,
+			LatestVersion: "unknown",
+			Registry:      registry,
+		},
+		Reference: reference,
+	}
+	if tag != "" {
+		result.CurrentVersion = StringPtr(tag)
+	}
+	if digest != "" {
+		result.CurrentDigest = StringPtr(digest)
+	}
+	if strings.Contains(reference, "$") {
+		result.Skipped = true
+		result.SkipReason = "Image reference contains an unresolved variable"
+		return result
+	}
+	if strings.Contains(platform, "$") {
+		platform = ""
+	}
+
+	query := DockerImageQuery{
+		Image:         image,
+		Registry:      registry,
+		Limit:         1,
+		IncludeDigest: digest != "",
+		Platform:      platform,
+	}
+	if registry == "custom" {
+		query.CustomRegistry = host
+	}
+
+	// Images without a version tag recommend the newest version of the default variant. A
+	// pinned digest is only out of date when the latest tag itself has moved away from it.
+	if tag == "" || tag == "latest" {
+		allTagsQuery := query
+		allTagsQuery.Limit = math.MaxInt32
+		allTagsQuery.IncludeDigest = false
+		allTagsQuery.Platform = ""
+		tags, err := h.getTags(allTagsQuery)
+		if err == nil {
+			var candidates []dockerTagVersion
+			for _, candidate := range tags {
+				if parts, variant, ok := parseDockerTagVersion(candidate.Tag); ok && variant == "" {
+					candidates = append(candidates, dockerTagVersion{tag: candidate, parts: parts})
+				}
+			}
+			newestQuery := query
+			newestQuery.IncludeDigest = false
+			var newest *dockerTagVersion
+			var latest DockerImageVersion
+			if newest, latest, err = h.resolveNewestTag(newestQuery, candidates); err == nil && newest != nil {
+				result.LatestVersion = latest.Tag
+				if digest != "" {
+					result.LatestDigest, err = h.getTagDigest(query, "latest")
+					if err != nil {
+						h.logger.WithFields(logrus.Fields{
+							"image": image,
+							"error": err.Error(),
+						}).Warn("Failed to resolve the digest of the latest tag")
+					}
+					result.UpdateAvailable = result.LatestDigest != nil && *result.LatestDigest != digest
+				}
+				return result
+			}
+		}
+		result.Skipped = true
+		if err != nil {
+			result.SkipReason = fmt.Sprintf("Failed to fetch image tags: %v", err)
+		} else {
+			result.SkipReason = "No version tags found"
+		}
+		return result
+	}
+
+	recommendation, err := h.recommendTag(query, tag)
+	if err != nil {
+		result.Skipped = true
+		result.SkipReason = fmt.Sprintf("Failed to fetch image tags: %v", err)
+		return result
+	}
+	result.LatestVersion = recommendation.LatestVersion
+	result.LatestDigest = recommendation.Digest
+	result.Skipped = recommendation.Skipped
+	result.SkipReason = recommendation.SkipReason
+
+	// A pinned digest is out of date when the tag has moved, even if no newer tag exists
+	result.UpdateAvailable = recommendation.UpdateAvailable ||
+		(digest != "" && recommendation.Digest != nil && *recommendation.Digest != digest)
+
+	return result
+}
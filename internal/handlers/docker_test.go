@@ -2,12 +2,16 @@ package handlers
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"sync"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDockerHandler_GetLatestVersion(t *testing.T) {
@@ -75,6 +79,18 @@ func TestDockerHandler_GetLatestVersion(t *testing.T) {
 			wantErr:     true,
 			errorString: "missing required parameter for custom registry: customRegistry",
 		},
+		{
+			name: "Negative limit with platform filter",
+			args: map[string]interface{}{
+				"image":          "team/app",
+				"registry":       "custom",
+				"customRegistry": "registry.example.com",
+				"platform":       "linux/amd64",
+				"limit":          float64(-1),
+			},
+			wantErr:     true,
+			errorString: "invalid limit",
+		},
 	}
 
 	// Run test cases
@@ -166,3 +182,458 @@ func validateToolResult(t *testing.T, result *mcp.CallToolResult) {
 		assert.NotEmpty(t, textContent.Text, "Text content should not be empty")
 	}
 }
+
+// TestDockerHandler_CustomRegistry tests the OCI Distribution client against a
+// stand-in registry that requires a bearer token and paginates its tags
+func TestDockerHandler_CustomRegistry(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			// Credentials are passed through to the token service when configured
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "robot" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "repository:team/app:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token": "test-token"}`))
+			return
+		case r.Header.Get("Authorization") != "Bearer test-token":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+serverURL+`/token",service="registry.test",scope="repository:team/app:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/team/app/tags/list":
+			if r.URL.Query().Get("last") == "" {
+				w.Header().Set("Link", `</v2/team/app/tags/list?n=1000&last=1.1.0>; rel="next"`)
+				_, _ = w.Write([]byte(`{"name": "team/app", "tags": ["1.0.0", "1.1.0"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"name": "team/app", "tags": ["2.0.0", "latest"]}`))
+		case "/v2/team/app/manifests/2.0.0":
+			assert.Equal(t, http.MethodHead, r.Method)
+			w.Header().Set("Docker-Content-Digest", "sha256:abc123")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	handler := NewDockerHandler(logger, &sync.Map{})
+	handler.credentials = func(host string) *RegistryCredentials {
+		return &RegistryCredentials{Username: "robot", Password: "secret"}
+	}
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image":          "team/app",
+		"registry":       "custom",
+		"customRegistry": server.URL,
+		"filterTags":     []interface{}{`^2\.`},
+		"includeDigest":  true,
+	})
+	require.NoError(t, err)
+
+	var tags []DockerImageVersion
+	unmarshalToolResult(t, result, &tags)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "2.0.0", tags[0].Tag)
+	assert.Equal(t, "custom", tags[0].Registry)
+	require.NotNil(t, tags[0].Digest)
+	assert.Equal(t, "sha256:abc123", *tags[0].Digest)
+
+	// All pages should have been followed
+	tags, err = handler.getCustomRegistryTags(DockerImageQuery{Image: "team/app", CustomRegistry: server.URL, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, tags, 4)
+}
+
+// TestDockerHandler_GHCR tests the anonymous GHCR token handshake for a nested image path
+func TestDockerHandler_GHCR(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			_, _, hasCredentials := r.BasicAuth()
+			assert.False(t, hasCredentials, "public images should use an anonymous token")
+			assert.Equal(t, "repository:owner/group/image:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token": "anonymous-token"}`))
+		case r.Header.Get("Authorization") != "Bearer anonymous-token":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:owner/group/image:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/owner/group/image/tags/list":
+			_, _ = w.Write([]byte(`{"name": "owner/group/image", "tags": ["v1.0.0", "v1.1.0"]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+
+	handler := NewDockerHandler(logger, &sync.Map{})
+	handler.client = newRedirectClient(server)
+	handler.credentials = func(host string) *RegistryCredentials { return nil }
+
+	tags, err := handler.getGHCRTags(DockerImageQuery{Image: "ghcr.io/Owner/group/image", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "ghcr.io/owner/group/image", tags[0].Name)
+	assert.Equal(t, "ghcr", tags[0].Registry)
+}
+
+// TestDockerHandler_DockerHubSort tests Docker Hub pagination and sorting tags by variant
+func TestDockerHandler_DockerHubSort(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/v2/repositories/library/node/tags", r.URL.Path)
+		switch r.URL.Query().Get("page") {
+		case "":
+			_, _ = w.Write([]byte(`{"next": "https://hub.docker.com/v2/repositories/library/node/tags?page=2&page_size=100", "results": [
+				{"name": "latest", "last_updated": "2025-03-05T00:00:00Z"},
+				{"name": "20.11.0-alpine", "last_updated": "2025-01-01T00:00:00Z"},
+				{"name": "20.9.0", "last_updated": "2025-02-01T00:00:00Z"},
+				{"name": "22.0.0-rc.1", "last_updated": "2025-03-01T00:00:00Z"}
+			]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"next": "https://hub.docker.com/v2/repositories/library/node/tags?page=3&page_size=100", "results": [
+				{"name": "20.11.0", "last_updated": "2025-01-01T00:00:00Z"},
+				{"name": "20.11", "last_updated": "2025-01-02T00:00:00Z"},
+				{"name": "18.19.0-bookworm", "last_updated": "2025-01-03T00:00:00Z"},
+				{"name": "18.20.0-alpine", "last_updated": "2024-12-01T00:00:00Z"}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"next": null, "results": [
+				{"name": "21.0.0", "last_updated": "2024-11-01T00:00:00Z"}
+			]}`))
+		}
+	}))
+	defer server.Close()
+
+	handler := NewDockerHandler(logger, &sync.Map{})
+	handler.client = newRedirectClient(server)
+
+	// The page cap stops before the third page
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image":    "node",
+		"sort":     "semver",
+		"maxPages": float64(2),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	var tags []DockerImageVersion
+	unmarshalToolResult(t, result, &tags)
+	require.Len(t, tags, 3)
+	assert.Equal(t, "20.11.0", tags[0].Tag)
+	assert.Equal(t, "20.11.0-alpine", tags[1].Tag)
+	require.NotNil(t, tags[1].Variant)
+	assert.Equal(t, "alpine", *tags[1].Variant)
+	assert.Equal(t, "18.19.0-bookworm", tags[2].Tag)
+
+	// Sorting by update time picks the most recently pushed tag of each variant
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image": "node",
+		"sort":  "updated",
+		"limit": float64(2),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, requests)
+
+	unmarshalToolResult(t, result, &tags)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "20.9.0", tags[0].Tag)
+	assert.Equal(t, "18.19.0-bookworm", tags[1].Tag)
+
+	// Unknown sort orders are rejected
+	_, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image": "node",
+		"sort":  "alphabetical",
+	})
+	assert.EqualError(t, err, "invalid sort: alphabetical")
+}
+
+// TestDockerHandler_RecommendTag tests recommending the newest tag of the same variant and precision
+func TestDockerHandler_RecommendTag(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"next": null, "results": [
+			{"name": "3.13.2-slim-bookworm", "last_updated": "2025-03-01T00:00:00Z", "images": [{"digest": "sha256:full"}]},
+			{"name": "3.13-slim-bookworm", "last_updated": "2025-03-01T00:00:00Z", "images": [{"digest": "sha256:minor"}]},
+			{"name": "3.13-alpine", "last_updated": "2025-03-01T00:00:00Z"},
+			{"name": "3.14-rc-slim-bookworm", "last_updated": "2025-03-01T00:00:00Z"},
+			{"name": "3.12-slim-bookworm", "last_updated": "2025-02-01T00:00:00Z"},
+			{"name": "3.11-slim-bookworm", "last_updated": "2025-01-01T00:00:00Z"},
+			{"name": "slim-bookworm", "last_updated": "2025-03-01T00:00:00Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	handler := NewDockerHandler(logger, &sync.Map{})
+	handler.client = newRedirectClient(server)
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image":         "python",
+		"currentTag":    "3.11-slim-bookworm",
+		"includeDigest": true,
+	})
+	require.NoError(t, err)
+
+	var recommendation DockerTagRecommendation
+	unmarshalToolResult(t, result, &recommendation)
+	assert.Equal(t, "python", recommendation.Name)
+	require.NotNil(t, recommendation.CurrentVersion)
+	assert.Equal(t, "3.11-slim-bookworm", *recommendation.CurrentVersion)
+	assert.Equal(t, "3.13-slim-bookworm", recommendation.LatestVersion)
+	assert.Equal(t, "slim-bookworm", recommendation.Variant)
+	assert.Equal(t, 2, recommendation.Precision)
+	assert.True(t, recommendation.UpdateAvailable)
+	require.NotNil(t, recommendation.Digest)
+	assert.Equal(t, "sha256:minor", *recommendation.Digest)
+
+	// Tags that aren't versions can't be compared
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image":      "python",
+		"currentTag": "slim-bookworm",
+	})
+	require.NoError(t, err)
+
+	recommendation = DockerTagRecommendation{}
+	unmarshalToolResult(t, result, &recommendation)
+	assert.True(t, recommendation.Skipped)
+	assert.Equal(t, "unknown", recommendation.LatestVersion)
+}
+
+// TestDockerHandler_Platforms tests reporting and filtering tags by platform
+func TestDockerHandler_Platforms(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	t.Run("Docker Hub", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"next": null, "results": [
+				{"name": "1.1.0", "digest": "sha256:index110", "last_updated": "2025-02-01T00:00:00Z", "images": [
+					{"os": "linux", "architecture": "amd64", "digest": "sha256:amd64", "size": 100}
+				]},
+				{"name": "1.0.0", "digest": "sha256:index100", "last_updated": "2025-01-01T00:00:00Z", "images": [
+					{"os": "linux", "architecture": "amd64", "digest": "sha256:amd64old", "size": 90},
+					{"os": "linux", "architecture": "arm64", "variant": "v8", "digest": "sha256:arm64old", "size": 95},
+					{"os": "unknown", "architecture": "unknown", "digest": "sha256:attestation", "size": 1}
+				]}
+			]}`))
+		}))
+		defer server.Close()
+
+		handler := NewDockerHandler(logger, &sync.Map{})
+		handler.client = newRedirectClient(server)
+
+		result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+			"image":     "team/app",
+			"platforms": true,
+			"platform":  "linux/arm64",
+		})
+		require.NoError(t, err)
+
+		var tags []DockerImageVersion
+		unmarshalToolResult(t, result, &tags)
+		require.Len(t, tags, 1)
+		assert.Equal(t, "1.0.0", tags[0].Tag)
+		require.NotNil(t, tags[0].Digest)
+		assert.Equal(t, "sha256:index100", *tags[0].Digest)
+		require.Len(t, tags[0].Platforms, 2)
+		assert.Equal(t, DockerImagePlatform{OS: "linux", Architecture: "arm64", Variant: "v8", Digest: "sha256:arm64old", Size: 95}, tags[0].Platforms[1])
+
+		// Platforms are omitted unless requested
+		result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+			"image": "team/app",
+		})
+		require.NoError(t, err)
+
+		tags = nil
+		unmarshalToolResult(t, result, &tags)
+		require.Len(t, tags, 2)
+		assert.Nil(t, tags[0].Platforms)
+		assert.Nil(t, tags[0].Digest)
+
+		_, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+			"image":    "team/app",
+			"platform": "arm64",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("OCI image index", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v2/team/app/tags/list":
+				_, _ = w.Write([]byte(`{"name": "team/app", "tags": ["1.0.0", "2.0.0"]}`))
+			case "/v2/team/app/manifests/2.0.0":
+				w.Header().Set("Docker-Content-Digest", "sha256:single")
+				_, _ = w.Write([]byte(`{"mediaType": "application/vnd.oci.image.manifest.v1+json",
+					"config": {"digest": "sha256:config", "size": 10}, "layers": [{"size": 20}]}`))
+			case "/v2/team/app/blobs/sha256:config":
+				_, _ = w.Write([]byte(`{"os": "linux", "architecture": "amd64"}`))
+			case "/v2/team/app/manifests/1.0.0":
+				w.Header().Set("Docker-Content-Digest", "sha256:index")
+				_, _ = w.Write([]byte(`{"mediaType": "application/vnd.oci.image.index.v1+json", "manifests": [
+					{"digest": "sha256:arm", "platform": {"os": "linux", "architecture": "arm", "variant": "v7"}},
+					{"digest": "sha256:att", "platform": {"os": "unknown", "architecture": "unknown"}}
+				]}`))
+			case "/v2/team/app/manifests/sha256:arm":
+				_, _ = w.Write([]byte(`{"config": {"size": 5}, "layers": [{"size": 30}, {"size": 40}]}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		handler := NewDockerHandler(logger, &sync.Map{})
+		handler.credentials = func(host string) *RegistryCredentials { return nil }
+
+		result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+			"image":          "team/app",
+			"registry":       "custom",
+			"customRegistry": server.URL,
+			"platforms":      true,
+		})
+		require.NoError(t, err)
+
+		var tags []DockerImageVersion
+		unmarshalToolResult(t, result, &tags)
+		require.Len(t, tags, 2)
+		require.NotNil(t, tags[0].Digest)
+		assert.Equal(t, "sha256:index", *tags[0].Digest)
+		assert.Equal(t, []DockerImagePlatform{{OS: "linux", Architecture: "arm", Variant: "v7", Digest: "sha256:arm", Size: 75}}, tags[0].Platforms)
+		assert.Equal(t, []DockerImagePlatform{{OS: "linux", Architecture: "amd64", Digest: "sha256:single", Size: 30}}, tags[1].Platforms)
+
+		result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+			"image":          "team/app",
+			"registry":       "custom",
+			"customRegistry": server.URL,
+			"platform":       "linux/arm/v7",
+		})
+		require.NoError(t, err)
+
+		tags = nil
+		unmarshalToolResult(t, result, &tags)
+		require.Len(t, tags, 1)
+		assert.Equal(t, "1.0.0", tags[0].Tag)
+		assert.Nil(t, tags[0].Platforms)
+		assert.Nil(t, tags[0].Digest)
+	})
+}
+
+// TestDetectRegistry tests detecting the registry from an image reference
+func TestDetectRegistry(t *testing.T) {
+	tests := []struct {
+		image    string
+		registry string
+		host     string
+	}{
+		{image: "nginx", registry: "dockerhub"},
+		{image: "grafana/grafana", registry: "dockerhub"},
+		{image: "docker.io/library/nginx", registry: "dockerhub", host: "docker.io"},
+		{image: "ghcr.io/owner/repo", registry: "ghcr", host: "ghcr.io"},
+		{image: "quay.io/prometheus/node-exporter", registry: "quay", host: "quay.io"},
+		{image: "public.ecr.aws/docker/library/nginx", registry: "ecr-public", host: "public.ecr.aws"},
+		{image: "mcr.microsoft.com/dotnet/sdk", registry: "mcr", host: "mcr.microsoft.com"},
+		{image: "us-docker.pkg.dev/project/repo/image", registry: "gar", host: "us-docker.pkg.dev"},
+		{image: "localhost:5000/app", registry: "custom", host: "localhost:5000"},
+		{image: "harbor.example.com/team/app", registry: "custom", host: "harbor.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			registry, host := detectRegistry(tt.image)
+			assert.Equal(t, tt.registry, registry)
+			assert.Equal(t, tt.host, host)
+		})
+	}
+}
+
+// TestDockerHandler_NamedRegistry tests auto-detecting Quay.io from a fully qualified image
+func TestDockerHandler_NamedRegistry(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "quay.io", r.Host)
+		switch {
+		case r.URL.Path == "/v2/auth":
+			assert.Equal(t, "repository:prometheus/node-exporter:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token": "quay-token"}`))
+		case r.Header.Get("Authorization") != "Bearer quay-token":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="https://quay.io/v2/auth",service="quay.io",scope="repository:prometheus/node-exporter:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/prometheus/node-exporter/tags/list":
+			_, _ = w.Write([]byte(`{"name": "prometheus/node-exporter", "tags": ["v1.8.0", "v1.9.0"]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	handler := NewDockerHandler(logger, &sync.Map{})
+	handler.client = newRedirectClient(server)
+	handler.credentials = func(host string) *RegistryCredentials { return nil }
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image": "quay.io/prometheus/node-exporter",
+	})
+	require.NoError(t, err)
+
+	var tags []DockerImageVersion
+	unmarshalToolResult(t, result, &tags)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "quay.io/prometheus/node-exporter", tags[0].Name)
+	assert.Equal(t, "quay", tags[0].Registry)
+
+	// Artifact Registry images must include their regional host
+	_, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"image":    "project/repo/image",
+		"registry": "gar",
+	})
+	assert.Error(t, err)
+}
+
+// redirectClient sends every request to a test server regardless of the requested host
+type redirectClient struct {
+	server *httptest.Server
+}
+
+// newRedirectClient creates a client that redirects all requests to the given test server
+func newRedirectClient(server *httptest.Server) *redirectClient {
+	return &redirectClient{server: server}
+}
+
+// Do implements the HTTPClient interface
+func (c *redirectClient) Do(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(c.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	return c.server.Client().Do(req)
+}
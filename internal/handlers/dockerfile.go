@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// dockerfileVariableRegex matches $NAME, ${NAME} and ${NAME:-default} style substitutions
+	dockerfileVariableRegex = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)(?::?([-+])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+)
+
+// DockerfileHandler handles checking the base images of a Dockerfile
+type DockerfileHandler struct {
+	logger *logrus.Logger
+	docker *DockerHandler
+}
+
+// NewDockerfileHandler creates a new Dockerfile handler
+func NewDockerfileHandler(logger *logrus.Logger, cache *sync.Map) *DockerfileHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &DockerfileHandler{
+		logger: logger,
+		docker: NewDockerHandler(logger, cache),
+	}
+}
+
+// DockerfileFrom represents a FROM instruction in a Dockerfile
+type DockerfileFrom struct {
+	// Reference is the image reference as written, before ARG substitution
+	Reference string
+	// Image is the image reference after ARG substitution
+	Image    string
+	Platform string
+	Stage    string
+	Line     int
+}
+
+// dockerfileInstruction is a single instruction with line continuations joined
+type dockerfileInstruction struct {
+	keyword string
+	args    string
+	line    int
+}
+
+// splitDockerfileInstructions splits a Dockerfile into instructions, joining lines ending
+// with a backslash and skipping comments and blank lines
+func splitDockerfileInstructions(dockerfile string) []dockerfileInstruction {
+	var instructions []dockerfileInstruction
+	var current strings.Builder
+	startLine := 0
+
+	for i, line := range strings.Split(strings.ReplaceAll(dockerfile, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || (trimmed == "" && current.Len() == 0) {
+			continue
+		}
+		if current.Len() == 0 {
+			startLine = i + 1
+		}
+
+		if strings.HasSuffix(trimmed, "\\") {
+			current.WriteString(strings.TrimSuffix(trimmed, "\\"))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(trimmed)
+
+		keyword, args, _ := strings.Cut(strings.TrimSpace(current.String()), " ")
+		instructions = append(instructions, dockerfileInstruction{
+			keyword: strings.ToUpper(keyword),
+			args:    strings.TrimSpace(args),
+			line:    startLine,
+		})
+		current.Reset()
+	}
+
+	return instructions
+}
+
+// expandDockerfileVariables substitutes ARG values into a FROM instruction, leaving
+// variables without a value (such as $BUILDPLATFORM) untouched
+func expandDockerfileVariables(value string, args map[string]string) string {
+	return dockerfileVariableRegex.ReplaceAllStringFunc(value, func(match string) string {
+		parts := dockerfileVariableRegex.FindStringSubmatch(match)
+		name := parts[1]
+		if name == "" {
+			name = parts[4]
+		}
+		argValue, ok := args[name]
+		switch parts[2] {
+		case "-":
+			if !ok || argValue == "" {
+				return parts[3]
+			}
+		case "+":
+			if ok && argValue != "" {
+				return parts[3]
+			}
+			return ""
+		}
+		if !ok {
+			return match
+		}
+		return argValue
+	})
+}
+
+// parseDockerfileArg parses the arguments of an ARG instruction into a name and default
+func parseDockerfileArg(args string) (name, value string, hasValue bool) {
+	name, value, hasValue = strings.Cut(args, "=")
+	return strings.TrimSpace(name), strings.Trim(strings.TrimSpace(value), `"'`), hasValue
+}
+
+// parseDockerfile extracts the FROM instructions of a Dockerfile. ARGs declared before the
+// first FROM are substituted using their defaults unless overridden by buildArgs.
+func parseDockerfile(dockerfile string, buildArgs map[string]string) ([]DockerfileFrom, error) {
+	args := make(map[string]string)
+	var froms []DockerfileFrom
+
+	for _, instruction := range splitDockerfileInstructions(dockerfile) {
+		switch instruction.keyword {
+		case "ARG":
+			// ARGs inside a build stage can't be used in FROM instructions
+			if len(froms) > 0 {
+				continue
+			}
+			name, value, hasValue := parseDockerfileArg(instruction.args)
+			if override, ok := buildArgs[name]; ok {
+				args[name] = override
+			} else if hasValue {
+				args[name] = expandDockerfileVariables(value, args)
+			}
+		case "FROM":
+			from := DockerfileFrom{Line: instruction.line}
+			fields := strings.Fields(instruction.args)
+			for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+				if platform, ok := strings.CutPrefix(fields[0], "--platform="); ok {
+					from.Platform = expandDockerfileVariables(platform, args)
+				}
+				fields = fields[1:]
+			}
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("line %d: FROM instruction is missing an image", instruction.line)
+			}
+			from.Reference = fields[0]
+			from.Image = expandDockerfileVariables(fields[0], args)
+			if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+				from.Stage = fields[2]
+			}
+			froms = append(froms, from)
+		}
+	}
+
+	return froms, nil
+}
+
+// GetLatestVersion checks every base image in a Dockerfile for newer tags
+func (h *DockerfileHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Checking Dockerfile base images")
+
+	// Parse Dockerfile
+	dockerfile, ok := args["dockerfile"].(string)
+	if !ok || strings.TrimSpace(dockerfile) == "" {
+		return nil, fmt.Errorf("missing required parameter: dockerfile")
+	}
+
+	// Parse build args
+	buildArgs := make(map[string]string)
+	if buildArgsRaw, ok := args["buildArgs"].(map[string]interface{}); ok {
+		for name, value := range buildArgsRaw {
+			buildArgs[name] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	froms, err := parseDockerfile(dockerfile, buildArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Dockerfile: %w", err)
+	}
+
+	// Process each FROM instruction in order, skipping references to earlier stages
+	stages := make(map[string]bool)
+	results := make([]DockerImageUpdate, 0, len(froms))
+	for _, from := range froms {
+		results = append(results, h.processFrom(from, stages))
+		if from.Stage != "" {
+			stages[strings.ToLower(from.Stage)] = true
+		}
+	}
+
+	return NewToolResultJSON(results)
+}
+
+// processFrom checks a single FROM instruction
+func (h *DockerfileHandler) processFrom(from DockerfileFrom, stages map[string]bool) DockerImageUpdate {
+	h.logger.WithFields(logrus.Fields{
+		"image":    from.Image,
+		"platform": from.Platform,
+		"line":     from.Line,
+	}).Debug("Processing Dockerfile FROM instruction")
+
+	var result DockerImageUpdate
+	switch {
+	case stages[strings.ToLower(from.Image)]:
+		result = DockerImageUpdate{
+			PackageVersion: PackageVersion{
+				Name:       from.Image,
+				Skipped:    true,
+				SkipReason: fmt.Sprintf("References build stage %s", from.Image),
+			},
+		}
+	case strings.EqualFold(from.Image, "scratch"):
+		result = DockerImageUpdate{
+			PackageVersion: PackageVersion{
+				Name:       from.Image,
+				Skipped:    true,
+				SkipReason: "Empty scratch image",
+			},
+		}
+	default:
+		result = h.docker.checkImageReference(from.Image, from.Platform)
+	}
+
+	result.Reference = from.Reference
+	result.Platform = from.Platform
+	result.Stage = from.Stage
+	result.Line = from.Line
+
+	return result
+}
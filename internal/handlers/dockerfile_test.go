@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDockerfile = `# syntax=docker/dockerfile:1
+ARG PYTHON_VERSION=3.11
+ARG VARIANT="slim-bookworm"
+
+FROM --platform=$BUILDPLATFORM python:${PYTHON_VERSION}-${VARIANT} AS build
+ARG STAGE_ONLY=ignored
+RUN pip install \
+    --no-cache-dir requests
+
+FROM build as test
+FROM --platform=linux/arm64 \
+    nginx:1.25@sha256:old
+FROM scratch
+COPY --from=build /app /app
+`
+
+func TestParseDockerfile(t *testing.T) {
+	froms, err := parseDockerfile(testDockerfile, map[string]string{"VARIANT": "alpine"})
+	require.NoError(t, err)
+	require.Len(t, froms, 4)
+
+	assert.Equal(t, DockerfileFrom{
+		Reference: "python:${PYTHON_VERSION}-${VARIANT}",
+		Image:     "python:3.11-alpine",
+		Platform:  "$BUILDPLATFORM",
+		Stage:     "build",
+		Line:      5,
+	}, froms[0])
+	assert.Equal(t, "build", froms[1].Image)
+	assert.Equal(t, "test", froms[1].Stage)
+	assert.Equal(t, DockerfileFrom{
+		Reference: "nginx:1.25@sha256:old",
+		Image:     "nginx:1.25@sha256:old",
+		Platform:  "linux/arm64",
+		Line:      11,
+	}, froms[2])
+	assert.Equal(t, "scratch", froms[3].Image)
+
+	// Variables without a value are left for the caller to report
+	assert.Equal(t, "node:20-alpine", expandDockerfileVariables("node:${NODE_VERSION:-20}-alpine", nil))
+	assert.Equal(t, "node:$NODE_VERSION", expandDockerfileVariables("node:$NODE_VERSION", nil))
+
+	_, err = parseDockerfile("FROM --platform=linux/amd64", nil)
+	assert.Error(t, err)
+}
+
+func TestDockerfileHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/repositories/library/python/tags":
+			_, _ = w.Write([]byte(`{"next": null, "results": [
+				{"name": "3.13-slim-bookworm", "last_updated": "2025-03-01T00:00:00Z"},
+				{"name": "3.12-slim-bookworm", "last_updated": "2025-03-01T00:00:00Z"},
+				{"name": "3.13-alpine", "last_updated": "2025-03-01T00:00:00Z"}
+			]}`))
+		case "/v2/repositories/library/nginx/tags":
+			_, _ = w.Write([]byte(`{"next": null, "results": [
+				{"name": "latest", "digest": "sha256:new", "last_updated": "2025-03-01T00:00:00Z", "images": [
+					{"os": "linux", "architecture": "amd64"},
+					{"os": "linux", "architecture": "arm64"}
+				]},
+				{"name": "1.27", "digest": "sha256:amd64only", "last_updated": "2025-03-01T00:00:00Z", "images": [
+					{"os": "linux", "architecture": "amd64"}
+				]},
+				{"name": "1.26", "digest": "sha256:new", "last_updated": "2025-02-01T00:00:00Z", "images": [
+					{"os": "linux", "architecture": "amd64"},
+					{"os": "linux", "architecture": "arm64"}
+				]},
+				{"name": "1.25", "digest": "sha256:old", "last_updated": "2025-01-01T00:00:00Z", "images": [
+					{"os": "linux", "architecture": "arm64"}
+				]}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	handler := NewDockerfileHandler(logger, &sync.Map{})
+	handler.docker.client = newRedirectClient(server)
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dockerfile": testDockerfile,
+	})
+	require.NoError(t, err)
+
+	var updates []DockerImageUpdate
+	unmarshalToolResult(t, result, &updates)
+	require.Len(t, updates, 4)
+
+	assert.Equal(t, "python", updates[0].Name)
+	require.NotNil(t, updates[0].CurrentVersion)
+	assert.Equal(t, "3.11-slim-bookworm", *updates[0].CurrentVersion)
+	assert.Equal(t, "3.13-slim-bookworm", updates[0].LatestVersion)
+	assert.True(t, updates[0].UpdateAvailable)
+	assert.Equal(t, "build", updates[0].Stage)
+	assert.Equal(t, 5, updates[0].Line)
+
+	assert.True(t, updates[1].Skipped)
+	assert.Equal(t, "References build stage build", updates[1].SkipReason)
+
+	// The newest tag supporting linux/arm64 is recommended, along with its digest
+	assert.Equal(t, "nginx", updates[2].Name)
+	assert.Equal(t, "1.26", updates[2].LatestVersion)
+	require.NotNil(t, updates[2].CurrentDigest)
+	assert.Equal(t, "sha256:old", *updates[2].CurrentDigest)
+	require.NotNil(t, updates[2].LatestDigest)
+	assert.Equal(t, "sha256:new", *updates[2].LatestDigest)
+	assert.True(t, updates[2].UpdateAvailable)
+
+	assert.True(t, updates[3].Skipped)
+
+	// Untagged and latest references recommend the newest version, but only report an update
+	// when their pinned digest differs from the digest of the latest tag, which lags behind here
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dockerfile": "FROM nginx\nFROM nginx:latest@sha256:new\nFROM nginx@sha256:old\n",
+	})
+	require.NoError(t, err)
+
+	updates = nil
+	unmarshalToolResult(t, result, &updates)
+	require.Len(t, updates, 3)
+	for _, update := range updates {
+		assert.Equal(t, "1.27", update.LatestVersion, update.Reference)
+	}
+	assert.False(t, updates[0].UpdateAvailable)
+	assert.Nil(t, updates[0].LatestDigest)
+	assert.False(t, updates[1].UpdateAvailable)
+	require.NotNil(t, updates[1].LatestDigest)
+	assert.Equal(t, "sha256:new", *updates[1].LatestDigest)
+	assert.True(t, updates[2].UpdateAvailable)
+	require.NotNil(t, updates[2].LatestDigest)
+	assert.Equal(t, "sha256:new", *updates[2].LatestDigest)
+
+	// ARG values without a default can't be resolved
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dockerfile": "ARG TAG\nFROM python:$TAG\n",
+	})
+	require.NoError(t, err)
+
+	updates = nil
+	unmarshalToolResult(t, result, &updates)
+	require.Len(t, updates, 1)
+	assert.True(t, updates[0].Skipped)
+	assert.Equal(t, "python:$TAG", updates[0].Reference)
+}
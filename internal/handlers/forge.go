@@ -0,0 +1,665 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ForgeGitHub is the forge type for github.com and GitHub Enterprise Server
+	ForgeGitHub = "github"
+	// ForgeGitLab is the forge type for gitlab.com and self-managed GitLab
+	ForgeGitLab = "gitlab"
+	// ForgeGitea is the forge type for Gitea and Forgejo, including Codeberg
+	ForgeGitea = "gitea"
+
+	// DefaultForgeHost is the forge used when a reference doesn't name a host
+	DefaultForgeHost = "github.com"
+
+	// forgeMaxTagPages limits how many pages of tags are read from a forge
+	forgeMaxTagPages = 10
+)
+
+// ForgeConfig describes a git forge and where its API is served
+type ForgeConfig struct {
+	Type   string
+	Host   string
+	APIURL string
+	// Token authenticates API requests to GitHub forges
+	Token string
+}
+
+// defaultForgeConfigs lists the public forges that are recognised without configuration
+var defaultForgeConfigs = map[string]ForgeConfig{
+	"github.com":   {Type: ForgeGitHub, Host: "github.com", APIURL: GitHubAPIURL},
+	"gitlab.com":   {Type: ForgeGitLab, Host: "gitlab.com", APIURL: "https://gitlab.com/api/v4"},
+	"codeberg.org": {Type: ForgeGitea, Host: "codeberg.org", APIURL: "https://codeberg.org/api/v1"},
+	"gitea.com":    {Type: ForgeGitea, Host: "gitea.com", APIURL: "https://gitea.com/api/v1"},
+}
+
+// ForgeRelease represents a release published on a forge
+type ForgeRelease struct {
+	TagName     string
+	Name        string
+	PublishedAt string
+	Draft       bool
+	Prerelease  bool
+	URL         string
+}
+
+// ForgeTag represents a tag and the commit it points to
+type ForgeTag struct {
+	Name      string
+	CommitSHA string
+}
+
+// Forge reads releases, tags and files from the repositories of a git forge. Projects are
+// given by their path on the forge, such as owner/repo or group/subgroup/repo on GitLab.
+type Forge interface {
+	// Host returns the host the forge serves repositories from, such as github.com
+	Host() string
+	// Releases lists the releases of a project, newest first
+	Releases(project string) ([]ForgeRelease, error)
+	// Tags lists the tags of a project with the commits they point to
+	Tags(project string) ([]ForgeTag, error)
+	// TagCommit resolves the commit SHA a tag points to, dereferencing annotated tags
+	TagCommit(project, tag string) (string, error)
+	// File gets the raw content of a file at a ref
+	File(project, path, ref string) ([]byte, error)
+	// ReleaseURL returns the web URL of the release for a tag
+	ReleaseURL(project, tag string) string
+}
+
+// ForgeRepository represents the metadata of a repository
+type ForgeRepository struct {
+	Archived      bool
+	DefaultBranch string
+}
+
+// ForgeBatcher is implemented by forges that can load the releases, tags and metadata of
+// many repositories in a single request
+type ForgeBatcher interface {
+	// Prefetch loads many repositories at once, so later lookups are answered from the cache
+	Prefetch(projects []string) error
+	// Repository returns the metadata of a prefetched repository, or nil if it wasn't loaded
+	Repository(project string) *ForgeRepository
+	// HasTags reports whether every tag of a repository was prefetched
+	HasTags(project string) bool
+}
+
+// prefetchRepositories loads many repositories at once when the forge supports batching.
+// Only rate limit errors are returned, as other failures fall back to individual lookups.
+func prefetchRepositories(logger *logrus.Logger, forge Forge, projects []string) error {
+	batcher, ok := forge.(ForgeBatcher)
+	if !ok || len(projects) == 0 {
+		return nil
+	}
+
+	err := batcher.Prefetch(projects)
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return err
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"forge": forge.Host(),
+			"error": err.Error(),
+		}).Warn("Failed to prefetch repositories, falling back to individual requests")
+	}
+	return nil
+}
+
+// forgeRepository returns the prefetched metadata of a repository, if any
+func forgeRepository(forge Forge, project string) *ForgeRepository {
+	if batcher, ok := forge.(ForgeBatcher); ok {
+		return batcher.Repository(project)
+	}
+	return nil
+}
+
+// forgeHasTags reports whether the tags of a repository were prefetched, so listing them
+// doesn't need any further requests
+func forgeHasTags(forge Forge, project string) bool {
+	if batcher, ok := forge.(ForgeBatcher); ok {
+		return batcher.HasTags(project)
+	}
+	return false
+}
+
+// defaultForgeAPIURL returns the conventional API base URL of a self-hosted forge
+func defaultForgeAPIURL(forgeType, host string) string {
+	switch forgeType {
+	case ForgeGitHub:
+		if host == "github.com" {
+			return GitHubAPIURL
+		}
+		return fmt.Sprintf("https://%s/api/v3", host)
+	case ForgeGitLab:
+		return fmt.Sprintf("https://%s/api/v4", host)
+	default:
+		return fmt.Sprintf("https://%s/api/v1", host)
+	}
+}
+
+// parseForgeConfigs parses forge definitions such as
+// "ghe.example.com=github,git.example.com=gitea:https://git.example.com/api/v1". The API
+// URL is optional and defaults to the conventional path for the forge type.
+func parseForgeConfigs(value string) (map[string]ForgeConfig, error) {
+	configs := make(map[string]ForgeConfig)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		host, definition, ok := strings.Cut(entry, "=")
+		host = strings.ToLower(strings.TrimSpace(host))
+		if !ok || host == "" {
+			return nil, fmt.Errorf("invalid forge definition %q (expected host=type)", entry)
+		}
+		forgeType, apiURL, _ := strings.Cut(strings.TrimSpace(definition), ":")
+		forgeType = strings.ToLower(forgeType)
+		switch forgeType {
+		case ForgeGitHub, ForgeGitLab, ForgeGitea:
+		case "forgejo":
+			forgeType = ForgeGitea
+		default:
+			return nil, fmt.Errorf("unsupported forge type %q for %s (expected github, gitlab, gitea or forgejo)", forgeType, host)
+		}
+
+		if apiURL == "" {
+			apiURL = defaultForgeAPIURL(forgeType, host)
+		}
+		apiURL = strings.TrimSuffix(apiURL, "/")
+		configs[host] = ForgeConfig{Type: forgeType, Host: host, APIURL: apiURL}
+	}
+	return configs, nil
+}
+
+// forgeConfigsFromEnv returns the default forges together with any self-hosted forges
+// defined in the GIT_FORGES environment variable
+func forgeConfigsFromEnv(logger *logrus.Logger) map[string]ForgeConfig {
+	configs := make(map[string]ForgeConfig, len(defaultForgeConfigs))
+	for host, config := range defaultForgeConfigs {
+		configs[host] = config
+	}
+
+	custom, err := parseForgeConfigs(os.Getenv("GIT_FORGES"))
+	if err != nil {
+		logger.WithError(err).Warn("Ignoring invalid GIT_FORGES")
+		return configs
+	}
+	for host, config := range custom {
+		configs[host] = config
+	}
+
+	for host, config := range configs {
+		if config.Type == ForgeGitHub {
+			config.Token = lookupGitHubToken(host)
+			configs[host] = config
+		}
+	}
+	return configs
+}
+
+// lookupGitHubToken returns a token for a GitHub host, following the GitHub CLI: GITHUB_TOKEN
+// or GH_TOKEN for github.com, GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN for GitHub
+// Enterprise Server, and otherwise, when USE_GH_CLI_TOKEN is set, the oauth_token stored in
+// the GitHub CLI's hosts.yml
+func lookupGitHubToken(host string) string {
+	if host == "github.com" {
+		if token := GitHubTokenFromEnv(); token != "" {
+			return token
+		}
+	} else {
+		for _, name := range []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+			if token := os.Getenv(name); token != "" {
+				return token
+			}
+		}
+	}
+	if useCLIToken, _ := strconv.ParseBool(os.Getenv("USE_GH_CLI_TOKEN")); !useCLIToken {
+		return ""
+	}
+	return lookupGitHubCLIToken(host)
+}
+
+// lookupGitHubCLIToken reads the token for a host from the GitHub CLI config file
+// ($GH_CONFIG_DIR/hosts.yml, $XDG_CONFIG_HOME/gh/hosts.yml or ~/.config/gh/hosts.yml)
+func lookupGitHubCLIToken(host string) string {
+	configDir := os.Getenv("GH_CONFIG_DIR")
+	if configDir == "" {
+		if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+			configDir = filepath.Join(xdgConfigHome, "gh")
+		} else {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return ""
+			}
+			configDir = filepath.Join(homeDir, ".config", "gh")
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return ""
+	}
+	return hosts[host].OAuthToken
+}
+
+// newForge returns the forge for a host, using client for its API requests. Rate limits
+// reported by the forge are remembered in cache until they reset.
+func newForge(client HTTPClient, logger *logrus.Logger, cache *sync.Map, configs map[string]ForgeConfig, host string) (Forge, error) {
+	host = strings.ToLower(host)
+	if host == "" {
+		host = DefaultForgeHost
+	}
+	config, ok := configs[host]
+	if !ok {
+		return nil, fmt.Errorf("unknown forge host %s (add it to GIT_FORGES as host=github, host=gitlab or host=gitea)", host)
+	}
+
+	api := forgeAPI{client: client, logger: logger, cache: cache, config: config}
+	switch config.Type {
+	case ForgeGitLab:
+		return &gitLabForge{api}, nil
+	case ForgeGitea:
+		return &giteaForge{api}, nil
+	default:
+		return &gitHubForge{api}, nil
+	}
+}
+
+// forgeAPI makes requests to the API of a forge
+type forgeAPI struct {
+	client HTTPClient
+	logger *logrus.Logger
+	cache  *sync.Map
+	config ForgeConfig
+}
+
+// Host returns the host the forge serves repositories from
+func (a forgeAPI) Host() string {
+	return a.config.Host
+}
+
+// get makes a GET request to the forge API
+func (a forgeAPI) get(path string, headers map[string]string) ([]byte, error) {
+	body, _, err := a.do("GET", a.config.APIURL+path, headers, nil)
+	return body, err
+}
+
+// do makes a request to the forge API, failing fast while a rate limit reported by an
+// earlier request is still in effect, and returns the response body and headers
+func (a forgeAPI) do(method, apiURL string, headers map[string]string, requestBody []byte) ([]byte, http.Header, error) {
+	cacheKey := fmt.Sprintf("forge-rate-limit:%s", a.config.Host)
+	if cachedErr, ok := a.cache.Load(cacheKey); ok {
+		rateLimitErr := cachedErr.(*RateLimitError)
+		if rateLimitErr.ResetAt != nil && time.Now().Before(*rateLimitErr.ResetAt) {
+			return nil, nil, rateLimitErr
+		}
+		a.cache.Delete(cacheKey)
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"forge":  a.config.Host,
+		"apiURL": apiURL,
+	}).Debug("Fetching from forge API")
+
+	body, header, err := makeRateLimitedRequest(a.client, a.logger, method, apiURL, headers, requestBody)
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		a.cache.Store(cacheKey, rateLimitErr)
+	}
+	return body, header, err
+}
+
+// parseRepositoryURL splits a git repository URL such as https://host/owner/repo.git,
+// ssh://git@host/owner/repo or git@host:owner/repo into its host and project path
+func parseRepositoryURL(repositoryURL string) (host, project string, err error) {
+	repositoryURL = strings.TrimSpace(repositoryURL)
+	if !strings.Contains(repositoryURL, "://") {
+		// scp-like syntax, e.g. git@github.com:owner/repo.git
+		if address, path, ok := strings.Cut(repositoryURL, ":"); ok && strings.Contains(address, "@") {
+			repositoryURL = "ssh://" + address + "/" + path
+		} else {
+			repositoryURL = "https://" + repositoryURL
+		}
+	}
+
+	parsed, err := url.Parse(repositoryURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository URL %s: %w", repositoryURL, err)
+	}
+	project = strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	if parsed.Hostname() == "" || !strings.Contains(project, "/") {
+		return "", "", fmt.Errorf("invalid repository URL format: %s (expected host/owner/repo)", repositoryURL)
+	}
+	return strings.ToLower(parsed.Hostname()), project, nil
+}
+
+// gitHubForge reads repositories from github.com or GitHub Enterprise Server
+type gitHubForge struct {
+	forgeAPI
+}
+
+// request makes an authenticated request to the GitHub REST API when a token is configured
+func (f *gitHubForge) request(path, accept string) ([]byte, error) {
+	headers := map[string]string{
+		"Accept": accept,
+	}
+	if f.config.Token != "" {
+		headers["Authorization"] = "Bearer " + f.config.Token
+	}
+	return f.get(path, headers)
+}
+
+// Releases lists the releases of a repository
+func (f *gitHubForge) Releases(project string) ([]ForgeRelease, error) {
+	if releases, ok := f.prefetchedReleases(project); ok {
+		return releases, nil
+	}
+
+	body, err := f.request(fmt.Sprintf("/repos/%s/releases", project), "application/vnd.github.v3+json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	var releases []GitHubRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	results := make([]ForgeRelease, 0, len(releases))
+	for _, release := range releases {
+		results = append(results, ForgeRelease{
+			TagName:     release.TagName,
+			Name:        release.Name,
+			PublishedAt: release.PublishedAt,
+			Draft:       release.Draft,
+			Prerelease:  release.Prerelease,
+			URL:         release.HTMLURL,
+		})
+	}
+	return results, nil
+}
+
+// Tags lists the tags of a repository with the commits they point to
+func (f *gitHubForge) Tags(project string) ([]ForgeTag, error) {
+	if tags, ok := f.prefetchedTags(project); ok {
+		return tags, nil
+	}
+
+	var tags []ForgeTag
+	for page := 1; page <= forgeMaxTagPages; page++ {
+		body, err := f.request(fmt.Sprintf("/repos/%s/tags?per_page=100&page=%d", project, page), "application/vnd.github.v3+json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tags: %w", err)
+		}
+		var pageTags []GitHubTag
+		if err := json.Unmarshal(body, &pageTags); err != nil {
+			return nil, fmt.Errorf("failed to parse tags: %w", err)
+		}
+		for _, tag := range pageTags {
+			tags = append(tags, ForgeTag{Name: tag.Name, CommitSHA: tag.Commit.SHA})
+		}
+		if len(pageTags) < 100 {
+			break
+		}
+	}
+	return tags, nil
+}
+
+// TagCommit resolves the commit SHA a tag points to, dereferencing annotated tags
+func (f *gitHubForge) TagCommit(project, tag string) (string, error) {
+	if tags, ok := f.prefetchedTags(project); ok {
+		for _, prefetched := range tags {
+			if prefetched.Name == tag {
+				return prefetched.CommitSHA, nil
+			}
+		}
+	}
+
+	body, err := f.request(fmt.Sprintf("/repos/%s/git/ref/tags/%s", project, tag), "application/vnd.github.v3+json")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch tag ref: %w", err)
+	}
+	var ref GitHubGitRef
+	if err := json.Unmarshal(body, &ref); err != nil {
+		return "", fmt.Errorf("failed to parse tag ref: %w", err)
+	}
+
+	// Annotated tags point to a tag object, which may itself point to another tag
+	for depth := 0; ref.Object.Type == "tag" && depth < 5; depth++ {
+		body, err := f.request(fmt.Sprintf("/repos/%s/git/tags/%s", project, ref.Object.SHA), "application/vnd.github.v3+json")
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch annotated tag: %w", err)
+		}
+		ref = GitHubGitRef{}
+		if err := json.Unmarshal(body, &ref); err != nil {
+			return "", fmt.Errorf("failed to parse annotated tag: %w", err)
+		}
+	}
+	if ref.Object.Type != "commit" || ref.Object.SHA == "" {
+		return "", fmt.Errorf("tag %s does not point to a commit", tag)
+	}
+	return ref.Object.SHA, nil
+}
+
+// File gets the raw content of a file at a ref
+func (f *gitHubForge) File(project, path, ref string) ([]byte, error) {
+	return f.request(fmt.Sprintf("/repos/%s/contents/%s?ref=%s", project, path, url.QueryEscape(ref)), "application/vnd.github.raw+json")
+}
+
+// ReleaseURL returns the web URL of the release for a tag
+func (f *gitHubForge) ReleaseURL(project, tag string) string {
+	return fmt.Sprintf("https://%s/%s/releases/tag/%s", f.config.Host, project, tag)
+}
+
+// gitLabForge reads projects from gitlab.com or a self-managed GitLab instance
+type gitLabForge struct {
+	forgeAPI
+}
+
+// GitLabRelease represents a release from the GitLab releases API
+type GitLabRelease struct {
+	TagName         string `json:"tag_name"`
+	Name            string `json:"name"`
+	ReleasedAt      string `json:"released_at"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+}
+
+// GitLabTag represents a tag from the GitLab repository tags API
+type GitLabTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// request makes a request to the GitLab REST API for a project
+func (f *gitLabForge) request(project, path string) ([]byte, error) {
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+	return f.get(fmt.Sprintf("/projects/%s%s", url.PathEscape(project), path), headers)
+}
+
+// Releases lists the releases of a project
+func (f *gitLabForge) Releases(project string) ([]ForgeRelease, error) {
+	body, err := f.request(project, "/releases")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	var releases []GitLabRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	// Upcoming releases have a release date in the future, much like a draft
+	results := make([]ForgeRelease, 0, len(releases))
+	for _, release := range releases {
+		results = append(results, ForgeRelease{
+			TagName:     release.TagName,
+			Name:        release.Name,
+			PublishedAt: release.ReleasedAt,
+			Draft:       release.UpcomingRelease,
+			URL:         f.ReleaseURL(project, release.TagName),
+		})
+	}
+	return results, nil
+}
+
+// Tags lists the tags of a project with the commits they point to
+func (f *gitLabForge) Tags(project string) ([]ForgeTag, error) {
+	var tags []ForgeTag
+	for page := 1; page <= forgeMaxTagPages; page++ {
+		body, err := f.request(project, fmt.Sprintf("/repository/tags?per_page=100&page=%d", page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tags: %w", err)
+		}
+		var pageTags []GitLabTag
+		if err := json.Unmarshal(body, &pageTags); err != nil {
+			return nil, fmt.Errorf("failed to parse tags: %w", err)
+		}
+		for _, tag := range pageTags {
+			tags = append(tags, ForgeTag{Name: tag.Name, CommitSHA: tag.Commit.ID})
+		}
+		if len(pageTags) < 100 {
+			break
+		}
+	}
+	return tags, nil
+}
+
+// TagCommit resolves the commit SHA a tag points to. GitLab always reports the commit of
+// annotated tags.
+func (f *gitLabForge) TagCommit(project, tag string) (string, error) {
+	body, err := f.request(project, "/repository/tags/"+url.PathEscape(tag))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch tag: %w", err)
+	}
+	var gitLabTag GitLabTag
+	if err := json.Unmarshal(body, &gitLabTag); err != nil {
+		return "", fmt.Errorf("failed to parse tag: %w", err)
+	}
+	if gitLabTag.Commit.ID == "" {
+		return "", fmt.Errorf("tag %s does not point to a commit", tag)
+	}
+	return gitLabTag.Commit.ID, nil
+}
+
+// File gets the raw content of a file at a ref
+func (f *gitLabForge) File(project, path, ref string) ([]byte, error) {
+	return f.request(project, fmt.Sprintf("/repository/files/%s/raw?ref=%s", url.PathEscape(path), url.QueryEscape(ref)))
+}
+
+// ReleaseURL returns the web URL of the release for a tag
+func (f *gitLabForge) ReleaseURL(project, tag string) string {
+	return fmt.Sprintf("https://%s/%s/-/releases/%s", f.config.Host, project, url.PathEscape(tag))
+}
+
+// giteaForge reads repositories from a Gitea or Forgejo instance such as Codeberg
+type giteaForge struct {
+	forgeAPI
+}
+
+// request makes a request to the Gitea REST API
+func (f *giteaForge) request(path string) ([]byte, error) {
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+	return f.get(path, headers)
+}
+
+// Releases lists the releases of a repository. Gitea's release payload matches GitHub's.
+func (f *giteaForge) Releases(project string) ([]ForgeRelease, error) {
+	body, err := f.request(fmt.Sprintf("/repos/%s/releases?limit=50", project))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	var releases []GitHubRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	results := make([]ForgeRelease, 0, len(releases))
+	for _, release := range releases {
+		results = append(results, ForgeRelease{
+			TagName:     release.TagName,
+			Name:        release.Name,
+			PublishedAt: release.PublishedAt,
+			Draft:       release.Draft,
+			Prerelease:  release.Prerelease,
+			URL:         release.HTMLURL,
+		})
+	}
+	return results, nil
+}
+
+// Tags lists the tags of a repository with the commits they point to
+func (f *giteaForge) Tags(project string) ([]ForgeTag, error) {
+	var tags []ForgeTag
+	for page := 1; page <= forgeMaxTagPages; page++ {
+		body, err := f.request(fmt.Sprintf("/repos/%s/tags?limit=50&page=%d", project, page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tags: %w", err)
+		}
+		var pageTags []GitHubTag
+		if err := json.Unmarshal(body, &pageTags); err != nil {
+			return nil, fmt.Errorf("failed to parse tags: %w", err)
+		}
+		for _, tag := range pageTags {
+			tags = append(tags, ForgeTag{Name: tag.Name, CommitSHA: tag.Commit.SHA})
+		}
+		if len(pageTags) < 50 {
+			break
+		}
+	}
+	return tags, nil
+}
+
+// TagCommit resolves the commit SHA a tag points to. Gitea always reports the commit of
+// annotated tags.
+func (f *giteaForge) TagCommit(project, tag string) (string, error) {
+	body, err := f.request(fmt.Sprintf("/repos/%s/tags/%s", project, url.PathEscape(tag)))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch tag: %w", err)
+	}
+	var giteaTag GitHubTag
+	if err := json.Unmarshal(body, &giteaTag); err != nil {
+		return "", fmt.Errorf("failed to parse tag: %w", err)
+	}
+	if giteaTag.Commit.SHA == "" {
+		return "", fmt.Errorf("tag %s does not point to a commit", tag)
+	}
+	return giteaTag.Commit.SHA, nil
+}
+
+// File gets the raw content of a file at a ref
+func (f *giteaForge) File(project, path, ref string) ([]byte, error) {
+	return f.request(fmt.Sprintf("/repos/%s/raw/%s?ref=%s", project, path, url.QueryEscape(ref)))
+}
+
+// ReleaseURL returns the web URL of the release for a tag
+func (f *giteaForge) ReleaseURL(project, tag string) string {
+	return fmt.Sprintf("https://%s/%s/releases/tag/%s", f.config.Host, project, tag)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseForgeConfigs(t *testing.T) {
+	configs, err := parseForgeConfigs("ghe.example.com=github, git.example.com=forgejo:https://git.example.com/forgejo/api/v1/,GitLab.Example.com=gitlab")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ForgeConfig{
+		"ghe.example.com":    {Type: ForgeGitHub, Host: "ghe.example.com", APIURL: "https://ghe.example.com/api/v3"},
+		"git.example.com":    {Type: ForgeGitea, Host: "git.example.com", APIURL: "https://git.example.com/forgejo/api/v1"},
+		"gitlab.example.com": {Type: ForgeGitLab, Host: "gitlab.example.com", APIURL: "https://gitlab.example.com/api/v4"},
+	}, configs)
+
+	_, err = parseForgeConfigs("git.example.com=bitbucket")
+	assert.Error(t, err)
+	_, err = parseForgeConfigs("git.example.com")
+	assert.Error(t, err)
+}
+
+func TestParseRepositoryURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		host    string
+		project string
+	}{
+		{"https://github.com/apple/swift-nio.git", "github.com", "apple/swift-nio"},
+		{"https://gitlab.example.com/group/subgroup/package", "gitlab.example.com", "group/subgroup/package"},
+		{"git@git.example.com:team/package.git", "git.example.com", "team/package"},
+		{"ssh://git@git.example.com:2222/team/package.git", "git.example.com", "team/package"},
+		{"github.com/apple/swift-log", "github.com", "apple/swift-log"},
+	}
+
+	for _, tt := range tests {
+		host, project, err := parseRepositoryURL(tt.url)
+		require.NoError(t, err, tt.url)
+		assert.Equal(t, tt.host, host, tt.url)
+		assert.Equal(t, tt.project, project, tt.url)
+	}
+
+	_, _, err := parseRepositoryURL("https://github.com/apple")
+	assert.Error(t, err)
+}
+
+func TestForges(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Stand-in API for each forge type, serving the same repository
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.RequestURI() {
+		// GitHub Enterprise Server
+		case "/ghe/api/v3/repos/team/tool/releases":
+			body = `[{"tag_name": "v2.0.0", "html_url": "https://ghe.example.com/team/tool/releases/tag/v2.0.0"}]`
+		case "/ghe/api/v3/repos/team/tool/tags?per_page=100&page=1":
+			body = `[{"name": "v2.0.0", "commit": {"sha": "abc"}}]`
+		case "/ghe/api/v3/repos/team/tool/git/ref/tags/v2.0.0":
+			body = `{"object": {"type": "commit", "sha": "abc"}}`
+		case "/ghe/api/v3/repos/team/tool/contents/action.yml?ref=v2.0.0":
+			body = "runs:\n  using: node20\n"
+		// GitLab, where the project path is URL encoded
+		case "/gitlab/api/v4/projects/team%2Ftool/releases":
+			body = `[{"tag_name": "v3.0.0", "upcoming_release": true}, {"tag_name": "v2.0.0", "released_at": "2024-01-01T00:00:00Z"}]`
+		case "/gitlab/api/v4/projects/team%2Ftool/repository/tags?per_page=100&page=1":
+			body = `[{"name": "v2.0.0", "commit": {"id": "abc"}}]`
+		case "/gitlab/api/v4/projects/team%2Ftool/repository/tags/v2.0.0":
+			body = `{"name": "v2.0.0", "commit": {"id": "abc"}}`
+		case "/gitlab/api/v4/projects/team%2Ftool/repository/files/action.yml/raw?ref=v2.0.0":
+			body = "runs:\n  using: node20\n"
+		// Gitea and Forgejo
+		case "/gitea/api/v1/repos/team/tool/releases?limit=50":
+			body = `[{"tag_name": "v2.0.0", "html_url": "https://gitea.example.com/team/tool/releases/tag/v2.0.0"}]`
+		case "/gitea/api/v1/repos/team/tool/tags?limit=50&page=1":
+			body = `[{"name": "v2.0.0", "commit": {"sha": "abc"}}]`
+		case "/gitea/api/v1/repos/team/tool/tags/v2.0.0":
+			body = `{"name": "v2.0.0", "commit": {"sha": "abc"}}`
+		case "/gitea/api/v1/repos/team/tool/raw/action.yml?ref=v2.0.0":
+			body = "runs:\n  using: node20\n"
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	configs := map[string]ForgeConfig{
+		"ghe.example.com":    {Type: ForgeGitHub, Host: "ghe.example.com", APIURL: server.URL + "/ghe/api/v3"},
+		"gitlab.example.com": {Type: ForgeGitLab, Host: "gitlab.example.com", APIURL: server.URL + "/gitlab/api/v4"},
+		"gitea.example.com":  {Type: ForgeGitea, Host: "gitea.example.com", APIURL: server.URL + "/gitea/api/v1"},
+	}
+
+	for host := range configs {
+		t.Run(host, func(t *testing.T) {
+			forge, err := newForge(server.Client(), logger, &sync.Map{}, configs, host)
+			require.NoError(t, err)
+			assert.Equal(t, host, forge.Host())
+
+			releases, err := forge.Releases("team/tool")
+			require.NoError(t, err)
+			var published []string
+			for _, release := range releases {
+				if !release.Draft {
+					published = append(published, release.TagName)
+				}
+			}
+			assert.Equal(t, []string{"v2.0.0"}, published)
+
+			tags, err := forge.Tags("team/tool")
+			require.NoError(t, err)
+			assert.Equal(t, []ForgeTag{{Name: "v2.0.0", CommitSHA: "abc"}}, tags)
+
+			sha, err := forge.TagCommit("team/tool", "v2.0.0")
+			require.NoError(t, err)
+			assert.Equal(t, "abc", sha)
+
+			content, err := forge.File("team/tool", "action.yml", "v2.0.0")
+			require.NoError(t, err)
+			assert.Equal(t, "runs:\n  using: node20\n", string(content))
+		})
+	}
+
+	_, err := newForge(server.Client(), logger, &sync.Map{}, configs, "git.unknown.example.com")
+	assert.Error(t, err)
+}
+
+func TestLookupGitHubToken(t *testing.T) {
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "hosts.yml"), []byte(`github.com:
+    user: octocat
+    oauth_token: gho_cli
+ghe.example.com:
+    oauth_token: gho_enterprise
+`), 0o600))
+	t.Setenv("GH_CONFIG_DIR", configDir)
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+
+	// The GitHub CLI config is only read when opted into
+	t.Setenv("USE_GH_CLI_TOKEN", "")
+	assert.Equal(t, "", lookupGitHubToken("github.com"))
+	assert.Equal(t, "", lookupGitHubToken("ghe.example.com"))
+
+	t.Setenv("USE_GH_CLI_TOKEN", "true")
+	assert.Equal(t, "gho_cli", lookupGitHubToken("github.com"))
+	assert.Equal(t, "gho_enterprise", lookupGitHubToken("ghe.example.com"))
+	assert.Equal(t, "", lookupGitHubToken("other.example.com"))
+
+	// Environment variables take precedence over the GitHub CLI config
+	t.Setenv("GH_TOKEN", "ghp_env")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "ghp_enterprise_env")
+	assert.Equal(t, "ghp_env", lookupGitHubToken("github.com"))
+	assert.Equal(t, "ghp_enterprise_env", lookupGitHubToken("ghe.example.com"))
+}
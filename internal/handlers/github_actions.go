@@ -2,14 +2,37 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// GitHubAPIURL is the base URL for the GitHub REST API
+	GitHubAPIURL = "https://api.github.com"
+	// compositeActionMaxDepth limits how deeply nested composite actions are followed
+	compositeActionMaxDepth = 3
+)
+
+// defaultDeprecatedActionRuntimes lists the runs.using values GitHub has retired or announced
+// the retirement of. GITHUB_ACTIONS_DEPRECATED_RUNTIMES replaces the list.
+var defaultDeprecatedActionRuntimes = []string{"node12", "node16", "node20"}
+
+var (
+	// commitSHARegex matches a full git commit SHA
+	commitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	// exactTagRegex matches an exact release tag such as v4.2.2
+	exactTagRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+	// majorTagRegex matches a floating major tag such as v4
+	majorTagRegex = regexp.MustCompile(`^v?\d+$`)
 )
 
 // GitHubActionsHandler handles GitHub Actions version checking
@@ -17,6 +40,9 @@ type GitHubActionsHandler struct {
 	client HTTPClient
 	cache  *sync.Map
 	logger *logrus.Logger
+	forges map[string]ForgeConfig
+	// deprecatedRuntimes holds the runs.using values flagged as deprecated
+	deprecatedRuntimes map[string]bool
 }
 
 // NewGitHubActionsHandler creates a new GitHub Actions handler
@@ -28,9 +54,29 @@ func NewGitHubActionsHandler(logger *logrus.Logger, cache *sync.Map) *GitHubActi
 		client: DefaultHTTPClient,
 		cache:  cache,
 		logger: logger,
+		forges: forgeConfigsFromEnv(logger),
+
+		deprecatedRuntimes: deprecatedRuntimesFromEnv(),
 	}
 }
 
+// deprecatedRuntimesFromEnv returns the deprecated action runtimes, read from the
+// comma-separated GITHUB_ACTIONS_DEPRECATED_RUNTIMES environment variable when set
+func deprecatedRuntimesFromEnv() map[string]bool {
+	runtimes := defaultDeprecatedActionRuntimes
+	if value, ok := os.LookupEnv("GITHUB_ACTIONS_DEPRECATED_RUNTIMES"); ok {
+		runtimes = strings.Split(value, ",")
+	}
+
+	deprecated := make(map[string]bool, len(runtimes))
+	for _, runtime := range runtimes {
+		if runtime = strings.ToLower(strings.TrimSpace(runtime)); runtime != "" {
+			deprecated[runtime] = true
+		}
+	}
+	return deprecated
+}
+
 // GitHubRelease represents a GitHub release
 type GitHubRelease struct {
 	TagName     string `json:"tag_name"`
@@ -41,10 +87,52 @@ type GitHubRelease struct {
 	HTMLURL     string `json:"html_url"`
 }
 
+// GitHubGitRef represents a response from the git refs and git tags APIs
+type GitHubGitRef struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		SHA  string `json:"sha"`
+		Type string `json:"type"`
+	} `json:"object"`
+}
+
+// GitHubTag represents a tag from the repository tags API
+type GitHubTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// GitHubActionMetadata represents the parts of an action.yml file used to check runtimes
+type GitHubActionMetadata struct {
+	Runs struct {
+		Using string `yaml:"using"`
+		Steps []struct {
+			Uses string `yaml:"uses"`
+		} `yaml:"steps"`
+	} `yaml:"runs"`
+}
+
 // GetLatestVersion gets the latest version of GitHub Actions
 func (h *GitHubActionsHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	h.logger.Debug("Getting latest GitHub Actions versions")
 
+	// Parse host, for actions hosted on GitHub Enterprise Server, Gitea or Forgejo
+	host := DefaultForgeHost
+	if hostRaw, ok := args["host"].(string); ok && hostRaw != "" {
+		host = strings.ToLower(hostRaw)
+	}
+	forge, err := newForge(h.client, h.logger, h.cache, h.forges, host)
+	if err != nil {
+		return nil, err
+	}
+
+	// Raw workflow YAML and uses: strings are resolved by reference
+	if args["workflow"] != nil || args["uses"] != nil {
+		return h.getLatestVersionsForReferences(forge, args)
+	}
+
 	// Parse actions
 	actionsRaw, ok := args["actions"]
 	if !ok {
@@ -83,6 +171,37 @@ func (h *GitHubActionsHandler) GetLatestVersion(ctx context.Context, args map[st
 		includeDetails = includeDetailsRaw
 	}
 
+	// Parse pin SHA
+	pinSHA := false
+	if pinSHARaw, ok := args["pinSHA"].(bool); ok {
+		pinSHA = pinSHARaw
+	}
+
+	// Parse check runtime
+	checkRuntime := false
+	if checkRuntimeRaw, ok := args["checkRuntime"].(bool); ok {
+		checkRuntime = checkRuntimeRaw
+	}
+
+	// Parse include tags. Listing every tag of an action costs several requests, so tags
+	// are only used unrequested when a batched query already loaded them.
+	includeTags := false
+	if includeTagsRaw, ok := args["includeTags"].(bool); ok {
+		includeTags = includeTagsRaw
+	}
+
+	// Load every repository at once when the forge supports batched queries
+	projects := make([]string, 0, len(actions))
+	for _, action := range actions {
+		projects = append(projects, fmt.Sprintf("%s/%s", action.Owner, action.Repo))
+	}
+	if err := prefetchRepositories(h.logger, forge, projects); err != nil {
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			return NewToolResultRateLimitError(rateLimitErr)
+		}
+	}
+
 	// Process each action
 	results := make([]GitHubActionVersion, 0, len(actions))
 	for _, action := range actions {
@@ -92,7 +211,11 @@ func (h *GitHubActionsHandler) GetLatestVersion(ctx context.Context, args map[st
 		}).Debug("Processing GitHub Action")
 
 		// Get latest version
-		latestVersion, publishedAt, url, err := h.getLatestVersion(action.Owner, action.Repo)
+		latestVersion, publishedAt, url, err := h.getLatestVersion(forge, action.Owner, action.Repo)
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			return NewToolResultRateLimitError(rateLimitErr)
+		}
 		if err != nil {
 			h.logger.WithFields(logrus.Fields{
 				"owner": action.Owner,
@@ -102,6 +225,7 @@ func (h *GitHubActionsHandler) GetLatestVersion(ctx context.Context, args map[st
 			results = append(results, GitHubActionVersion{
 				Owner:          action.Owner,
 				Repo:           action.Repo,
+				Host:           forgeHostLabel(forge),
 				CurrentVersion: action.CurrentVersion,
 				LatestVersion:  "unknown",
 			})
@@ -112,6 +236,7 @@ func (h *GitHubActionsHandler) GetLatestVersion(ctx context.Context, args map[st
 		result := GitHubActionVersion{
 			Owner:          action.Owner,
 			Repo:           action.Repo,
+			Host:           forgeHostLabel(forge),
 			CurrentVersion: action.CurrentVersion,
 			LatestVersion:  latestVersion,
 		}
@@ -122,6 +247,17 @@ func (h *GitHubActionsHandler) GetLatestVersion(ctx context.Context, args map[st
 			result.URL = StringPtr(url)
 		}
 
+		// Add repository metadata, floating major tags and commit SHAs if requested or pinned
+		addRepositoryInfo(&result, forge, action.Owner, action.Repo)
+		h.addTagInfo(&result, forge, action.Owner, action.Repo, includeTags || forgeHasTags(forge, fmt.Sprintf("%s/%s", action.Owner, action.Repo)))
+		if pinSHA {
+			h.pinToSHA(&result, forge, action.Owner, action.Repo, fmt.Sprintf("%s/%s", action.Owner, action.Repo))
+		}
+		h.verifyPinnedSHA(&result, forge, action.Owner, action.Repo)
+		if checkRuntime {
+			h.addRuntimeInfo(&result, forge, action.Owner, action.Repo, "")
+		}
+
 		results = append(results, result)
 	}
 
@@ -139,9 +275,9 @@ func (h *GitHubActionsHandler) GetLatestVersion(ctx context.Context, args map[st
 }
 
 // getLatestVersion gets the latest version of a GitHub Action
-func (h *GitHubActionsHandler) getLatestVersion(owner, repo string) (version, publishedAt, url string, err error) {
+func (h *GitHubActionsHandler) getLatestVersion(forge Forge, owner, repo string) (version, publishedAt, url string, err error) {
 	// Check cache first
-	cacheKey := fmt.Sprintf("github-action:%s/%s", owner, repo)
+	cacheKey := fmt.Sprintf("github-action:%s/%s/%s", forge.Host(), owner, repo)
 	if cachedInfo, ok := h.cache.Load(cacheKey); ok {
 		h.logger.WithFields(logrus.Fields{
 			"owner": owner,
@@ -151,29 +287,18 @@ func (h *GitHubActionsHandler) getLatestVersion(owner, repo string) (version, pu
 		return info["version"], info["publishedAt"], info["url"], nil
 	}
 
-	// Construct URL
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", owner, repo)
 	h.logger.WithFields(logrus.Fields{
-		"owner":  owner,
-		"repo":   repo,
-		"apiURL": apiURL,
+		"owner": owner,
+		"repo":  repo,
+		"forge": forge.Host(),
 	}).Debug("Fetching GitHub Action releases")
 
-	// Make request
-	headers := map[string]string{
-		"Accept": "application/vnd.github.v3+json",
-	}
-	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", apiURL, headers)
+	project := fmt.Sprintf("%s/%s", owner, repo)
+	releases, err := forge.Releases(project)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to fetch GitHub Action releases: %w", err)
 	}
 
-	// Parse response
-	var releases []GitHubRelease
-	if err := json.Unmarshal(body, &releases); err != nil {
-		return "", "", "", fmt.Errorf("failed to parse GitHub Action releases: %w", err)
-	}
-
 	// Find latest non-draft, non-prerelease version
 	for _, release := range releases {
 		if release.Draft || release.Prerelease {
@@ -184,48 +309,624 @@ func (h *GitHubActionsHandler) getLatestVersion(owner, repo string) (version, pu
 		info := map[string]string{
 			"version":     release.TagName,
 			"publishedAt": release.PublishedAt,
-			"url":         release.HTMLURL,
+			"url":         release.URL,
 		}
 		h.cache.Store(cacheKey, info)
 
-		return release.TagName, release.PublishedAt, release.HTMLURL, nil
+		return release.TagName, release.PublishedAt, release.URL, nil
 	}
 
 	// If no releases found, try tags
-	tagsURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/tags", owner, repo)
-	h.logger.WithFields(logrus.Fields{
-		"owner":   owner,
-		"repo":    repo,
-		"tagsURL": tagsURL,
-	}).Debug("Fetching GitHub Action tags")
-
-	// Make request
-	body, err = MakeRequestWithLogger(h.client, h.logger, "GET", tagsURL, headers)
+	tags, err := h.listTags(forge, owner, repo)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to fetch GitHub Action tags: %w", err)
 	}
 
-	// Parse response
-	var tags []struct {
-		Name string `json:"name"`
-	}
-	if err := json.Unmarshal(body, &tags); err != nil {
-		return "", "", "", fmt.Errorf("failed to parse GitHub Action tags: %w", err)
-	}
-
-	// Find latest version
+	// Find latest version, preferring the newest exact version tag
 	if len(tags) > 0 {
+		latestTag, _ := newestActionTags(tags)
+		if latestTag == "" {
+			latestTag = tags[0].Name
+		}
+
 		// Cache result
-		url := fmt.Sprintf("https://github.com/%s/%s/releases/tag/%s", owner, repo, tags[0].Name)
+		url := forge.ReleaseURL(project, latestTag)
 		info := map[string]string{
-			"version":     tags[0].Name,
+			"version":     latestTag,
 			"publishedAt": "",
 			"url":         url,
 		}
 		h.cache.Store(cacheKey, info)
 
-		return tags[0].Name, "", url, nil
+		return latestTag, "", url, nil
 	}
 
 	return "", "", "", fmt.Errorf("no releases or tags found for: %s/%s", owner, repo)
 }
+
+// forgeHostLabel returns the host of a forge for results, leaving it empty for github.com
+func forgeHostLabel(forge Forge) string {
+	if forge.Host() == DefaultForgeHost {
+		return ""
+	}
+	return forge.Host()
+}
+
+// GitHubActionUse represents a parsed `uses:` reference
+type GitHubActionUse struct {
+	Reference string
+	Owner     string
+	Repo      string
+	Path      string
+	Ref       string
+	// Host is set for references given as a full URL, such as https://gitea.com/actions/checkout@v4
+	Host string
+}
+
+// workflowUse is a `uses:` reference found in a workflow file with its YAML location
+type workflowUse struct {
+	reference string
+	location  string
+}
+
+// parseActionUse parses a `uses:` reference such as actions/checkout@v4,
+// github/codeql-action/init@v3 or owner/repo/.github/workflows/build.yml@main. Gitea and
+// Forgejo also accept full URLs such as https://code.forgejo.org/actions/checkout@v4. The
+// skip reason is set for references that don't come from a repository.
+func parseActionUse(reference string) (use GitHubActionUse, skipReason string) {
+	use.Reference = reference
+	switch {
+	case strings.HasPrefix(reference, "docker://"):
+		return use, "Docker container action"
+	case strings.HasPrefix(reference, "./") || strings.HasPrefix(reference, "../"):
+		return use, "Local action"
+	}
+
+	path, ref, found := strings.Cut(reference, "@")
+	if !found || ref == "" {
+		return use, "Missing version reference (expected owner/repo@ref)"
+	}
+	for _, scheme := range []string{"https://", "http://"} {
+		if rest, ok := strings.CutPrefix(path, scheme); ok {
+			use.Host, path, _ = strings.Cut(rest, "/")
+			use.Host = strings.ToLower(use.Host)
+			break
+		}
+	}
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return use, "Invalid action reference (expected owner/repo@ref)"
+	}
+
+	use.Owner = parts[0]
+	use.Repo = parts[1]
+	if len(parts) == 3 {
+		use.Path = parts[2]
+	}
+	use.Ref = ref
+	return use, ""
+}
+
+// parseWorkflowUses finds every job-level and step-level `uses:` reference in a workflow,
+// and the step references of a composite action's action.yml
+func parseWorkflowUses(content string) ([]workflowUse, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	document := root.Content[0]
+
+	var uses []workflowUse
+	addSteps := func(steps *yaml.Node, location string) {
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			return
+		}
+		for index, step := range steps.Content {
+			if use := mappingValue(step, "uses"); use != nil && use.Value != "" {
+				uses = append(uses, workflowUse{
+					reference: use.Value,
+					location:  fmt.Sprintf("%s[%d].uses", location, index),
+				})
+			}
+		}
+	}
+
+	if jobs := mappingValue(document, "jobs"); jobs != nil && jobs.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(jobs.Content); i += 2 {
+			jobID, job := jobs.Content[i].Value, jobs.Content[i+1]
+			if use := mappingValue(job, "uses"); use != nil && use.Value != "" {
+				uses = append(uses, workflowUse{
+					reference: use.Value,
+					location:  fmt.Sprintf("jobs.%s.uses", jobID),
+				})
+			}
+			addSteps(mappingValue(job, "steps"), fmt.Sprintf("jobs.%s.steps", jobID))
+		}
+	}
+	addSteps(mappingValue(mappingValue(document, "runs"), "steps"), "runs.steps")
+
+	return uses, nil
+}
+
+// getLatestVersionsForReferences resolves the `uses:` references of a workflow or a list
+// of `uses:` strings, returning results keyed by the original reference
+func (h *GitHubActionsHandler) getLatestVersionsForReferences(forge Forge, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	var uses []workflowUse
+
+	// Parse workflow
+	if workflowRaw, ok := args["workflow"]; ok && workflowRaw != nil {
+		workflow, ok := workflowRaw.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid workflow format: expected string")
+		}
+		workflowUses, err := parseWorkflowUses(workflow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse workflow: %w", err)
+		}
+		uses = append(uses, workflowUses...)
+	}
+
+	// Parse uses
+	if usesRaw, ok := args["uses"]; ok && usesRaw != nil {
+		usesArr, ok := usesRaw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid uses format: expected array of strings")
+		}
+		for _, useRaw := range usesArr {
+			if reference, ok := useRaw.(string); ok && reference != "" {
+				uses = append(uses, workflowUse{reference: strings.TrimSpace(reference)})
+			}
+		}
+	}
+
+	// Parse include details
+	includeDetails := false
+	if includeDetailsRaw, ok := args["includeDetails"].(bool); ok {
+		includeDetails = includeDetailsRaw
+	}
+
+	// Parse pin SHA
+	pinSHA := false
+	if pinSHARaw, ok := args["pinSHA"].(bool); ok {
+		pinSHA = pinSHARaw
+	}
+
+	// Parse check runtime
+	checkRuntime := false
+	if checkRuntimeRaw, ok := args["checkRuntime"].(bool); ok {
+		checkRuntime = checkRuntimeRaw
+	}
+
+	// Parse include tags. Listing every tag of an action costs several requests, so tags
+	// are only used unrequested when a batched query already loaded them.
+	includeTags := false
+	if includeTagsRaw, ok := args["includeTags"].(bool); ok {
+		includeTags = includeTagsRaw
+	}
+
+	// Load every repository at once, grouped by the forge hosting it, when the forge
+	// supports batched queries
+	forges := map[string]Forge{forge.Host(): forge}
+	projects := make(map[string][]string)
+	for _, use := range uses {
+		action, skipReason := parseActionUse(use.reference)
+		if skipReason != "" {
+			continue
+		}
+		actionForge, err := h.forgeForUse(forge, action)
+		if err != nil {
+			continue
+		}
+		forges[actionForge.Host()] = actionForge
+		projects[actionForge.Host()] = append(projects[actionForge.Host()], fmt.Sprintf("%s/%s", action.Owner, action.Repo))
+	}
+	for host, hostProjects := range projects {
+		if err := prefetchRepositories(h.logger, forges[host], hostProjects); err != nil {
+			var rateLimitErr *RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				return NewToolResultRateLimitError(rateLimitErr)
+			}
+		}
+	}
+
+	// Process each reference once, collecting where it's used
+	results := make(map[string]GitHubActionReference)
+	for _, use := range uses {
+		result, ok := results[use.reference]
+		if !ok {
+			var err error
+			result, err = h.processActionUse(forge, use.reference, includeDetails, pinSHA, checkRuntime, includeTags)
+			var rateLimitErr *RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				return NewToolResultRateLimitError(rateLimitErr)
+			}
+		}
+		if use.location != "" {
+			result.Locations = append(result.Locations, use.location)
+		}
+		results[use.reference] = result
+	}
+
+	return NewToolResultJSON(results)
+}
+
+// processActionUse resolves the latest version of a single `uses:` reference. References
+// without a host are resolved against the given forge. Other failures are reported on the
+// result, so an error is only returned when the forge's rate limit is exhausted.
+func (h *GitHubActionsHandler) processActionUse(forge Forge, reference string, includeDetails, pinSHA, checkRuntime, includeTags bool) (GitHubActionReference, error) {
+	h.logger.WithField("uses", reference).Debug("Processing GitHub Action reference")
+
+	use, skipReason := parseActionUse(reference)
+	result := GitHubActionReference{
+		GitHubActionVersion: GitHubActionVersion{
+			Owner:         use.Owner,
+			Repo:          use.Repo,
+			LatestVersion: "unknown",
+		},
+		Path:             use.Path,
+		ReusableWorkflow: strings.HasPrefix(use.Path, ".github/workflows/"),
+	}
+	if use.Ref != "" {
+		result.CurrentVersion = StringPtr(use.Ref)
+	}
+	if skipReason != "" {
+		result.Skipped = true
+		result.SkipReason = skipReason
+		return result, nil
+	}
+
+	forge, err := h.forgeForUse(forge, use)
+	if err != nil {
+		result.Skipped = true
+		result.SkipReason = err.Error()
+		return result, nil
+	}
+	result.Host = forgeHostLabel(forge)
+
+	// Subpath actions and reusable workflows are versioned with their repository
+	latestVersion, publishedAt, url, err := h.getLatestVersion(forge, use.Owner, use.Repo)
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return result, err
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"uses":  reference,
+			"error": err.Error(),
+		}).Error("Failed to get GitHub Action info")
+		result.Skipped = true
+		result.SkipReason = fmt.Sprintf("Failed to fetch releases: %v", err)
+		return result, nil
+	}
+
+	result.LatestVersion = latestVersion
+	if includeDetails {
+		result.PublishedAt = StringPtr(publishedAt)
+		result.URL = StringPtr(url)
+	}
+	addRepositoryInfo(&result.GitHubActionVersion, forge, use.Owner, use.Repo)
+	h.addTagInfo(&result.GitHubActionVersion, forge, use.Owner, use.Repo, includeTags || forgeHasTags(forge, fmt.Sprintf("%s/%s", use.Owner, use.Repo)))
+	if pinSHA {
+		action, _, _ := strings.Cut(reference, "@")
+		h.pinToSHA(&result.GitHubActionVersion, forge, use.Owner, use.Repo, action)
+	}
+	h.verifyPinnedSHA(&result.GitHubActionVersion, forge, use.Owner, use.Repo)
+
+	// Reusable workflows don't have an action.yml
+	if checkRuntime && !result.ReusableWorkflow {
+		h.addRuntimeInfo(&result.GitHubActionVersion, forge, use.Owner, use.Repo, use.Path)
+	}
+
+	return result, nil
+}
+
+// forgeForUse returns the forge hosting a `uses:` reference, which is the default forge
+// unless the reference is a full URL
+func (h *GitHubActionsHandler) forgeForUse(forge Forge, use GitHubActionUse) (Forge, error) {
+	if use.Host == "" || use.Host == forge.Host() {
+		return forge, nil
+	}
+	return newForge(h.client, h.logger, h.cache, h.forges, use.Host)
+}
+
+// pinToSHA adds the commit SHA of the latest version and a ready-to-paste pinned uses: line
+// for action, the part of the reference before the @
+func (h *GitHubActionsHandler) pinToSHA(result *GitHubActionVersion, forge Forge, owner, repo, action string) {
+	if result.LatestVersion == "" || result.LatestVersion == "unknown" {
+		return
+	}
+
+	sha, err := h.resolveTagCommit(forge, owner, repo, result.LatestVersion)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"owner": owner,
+			"repo":  repo,
+			"tag":   result.LatestVersion,
+			"error": err.Error(),
+		}).Error("Failed to resolve tag commit SHA")
+		return
+	}
+
+	result.LatestSHA = StringPtr(sha)
+	result.PinnedUses = StringPtr(fmt.Sprintf("uses: %s@%s # %s", action, sha, result.LatestVersion))
+}
+
+// verifyPinnedSHA checks whether a current version given as a full commit SHA is the
+// commit of one of the repository's tags
+func (h *GitHubActionsHandler) verifyPinnedSHA(result *GitHubActionVersion, forge Forge, owner, repo string) {
+	if result.CurrentVersion == nil || !commitSHARegex.MatchString(*result.CurrentVersion) {
+		return
+	}
+
+	tags, err := h.listTags(forge, owner, repo)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"owner": owner,
+			"repo":  repo,
+			"error": err.Error(),
+		}).Error("Failed to list tags to verify pinned SHA")
+		return
+	}
+
+	verified := false
+	for _, tag := range tags {
+		if tag.CommitSHA == *result.CurrentVersion {
+			result.CurrentSHATags = append(result.CurrentSHATags, tag.Name)
+			verified = true
+		}
+	}
+	result.CurrentSHAVerified = &verified
+}
+
+// resolveTagCommit resolves the commit SHA a tag points to, dereferencing annotated tags
+func (h *GitHubActionsHandler) resolveTagCommit(forge Forge, owner, repo, tag string) (string, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("github-tag-sha:%s/%s/%s@%s", forge.Host(), owner, repo, tag)
+	if cachedSHA, ok := h.cache.Load(cacheKey); ok {
+		return cachedSHA.(string), nil
+	}
+
+	sha, err := forge.TagCommit(fmt.Sprintf("%s/%s", owner, repo), tag)
+	if err != nil {
+		return "", err
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, sha)
+
+	return sha, nil
+}
+
+// listTags lists the tags of a repository with the commits they point to
+func (h *GitHubActionsHandler) listTags(forge Forge, owner, repo string) ([]ForgeTag, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("github-tags:%s/%s/%s", forge.Host(), owner, repo)
+	if cachedTags, ok := h.cache.Load(cacheKey); ok {
+		return cachedTags.([]ForgeTag), nil
+	}
+
+	tags, err := forge.Tags(fmt.Sprintf("%s/%s", owner, repo))
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, tags)
+
+	return tags, nil
+}
+
+// newestActionTags returns the newest exact version tag (e.g. v4.2.2) and the newest
+// floating major tag (e.g. v4) of a repository
+func newestActionTags(tags []ForgeTag) (exact, major string) {
+	for _, tag := range tags {
+		switch {
+		case exactTagRegex.MatchString(tag.Name):
+			if exact == "" || compareTagVersions(tag.Name, exact) > 0 {
+				exact = tag.Name
+			}
+		case majorTagRegex.MatchString(tag.Name):
+			if major == "" || compareTagVersions(tag.Name, major) > 0 {
+				major = tag.Name
+			}
+		}
+	}
+	return exact, major
+}
+
+// compareTagVersions compares two version tags, returning 0 if either can't be parsed
+func compareTagVersions(a, b string) int {
+	result, err := CompareVersions(a, b)
+	if err != nil {
+		return 0
+	}
+	return result
+}
+
+// addRepositoryInfo reports whether an action's repository is archived and its default
+// branch, when the repository was loaded by a batched query
+func addRepositoryInfo(result *GitHubActionVersion, forge Forge, owner, repo string) {
+	repository := forgeRepository(forge, fmt.Sprintf("%s/%s", owner, repo))
+	if repository == nil {
+		return
+	}
+	result.Archived = repository.Archived
+	if repository.DefaultBranch != "" {
+		result.DefaultBranch = StringPtr(repository.DefaultBranch)
+	}
+}
+
+// addTagInfo reports whether the current version is behind a newer major version and, when
+// listTags is set, the newest exact and floating major tags of an action
+func (h *GitHubActionsHandler) addTagInfo(result *GitHubActionVersion, forge Forge, owner, repo string, listTags bool) {
+	if result.CurrentVersion == nil {
+		return
+	}
+	current := *result.CurrentVersion
+
+	if listTags {
+		tags, err := h.listTags(forge, owner, repo)
+		if err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"owner": owner,
+				"repo":  repo,
+				"error": err.Error(),
+			}).Error("Failed to list GitHub Action tags")
+		}
+
+		exact, major := newestActionTags(tags)
+		if exact != "" {
+			result.LatestExactTag = StringPtr(exact)
+		}
+		if major != "" {
+			result.LatestMajorTag = StringPtr(major)
+		}
+
+		// Pinned SHAs are compared using the tags that point at them
+		if commitSHARegex.MatchString(current) {
+			current = ""
+			for _, tag := range tags {
+				if tag.CommitSHA == *result.CurrentVersion && (exactTagRegex.MatchString(tag.Name) || majorTagRegex.MatchString(tag.Name)) {
+					current = tag.Name
+					break
+				}
+			}
+		}
+	}
+
+	// The latest version is enough to spot a newer major, the tags only add to it
+	currentMajor, _, _, err := ParseVersion(current)
+	if err != nil || commitSHARegex.MatchString(current) {
+		return
+	}
+	newestMajor := -1
+	for _, tag := range []*string{result.LatestExactTag, result.LatestMajorTag, &result.LatestVersion} {
+		if tag == nil {
+			continue
+		}
+		if tagMajor, _, _, err := ParseVersion(*tag); err == nil && tagMajor > newestMajor {
+			newestMajor = tagMajor
+		}
+	}
+	result.BehindMajor = newestMajor > currentMajor
+}
+
+// getActionMetadata gets and parses the action.yml (or action.yaml) of an action at a ref
+func (h *GitHubActionsHandler) getActionMetadata(forge Forge, owner, repo, path, ref string) (*GitHubActionMetadata, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("github-action-metadata:%s/%s/%s/%s@%s", forge.Host(), owner, repo, path, ref)
+	if cachedMetadata, ok := h.cache.Load(cacheKey); ok {
+		return cachedMetadata.(*GitHubActionMetadata), nil
+	}
+
+	var body []byte
+	var err error
+	for _, filename := range []string{"action.yml", "action.yaml"} {
+		filePath := filename
+		if path != "" {
+			filePath = strings.Trim(path, "/") + "/" + filename
+		}
+		h.logger.WithFields(logrus.Fields{
+			"owner": owner,
+			"repo":  repo,
+			"file":  filePath,
+			"ref":   ref,
+		}).Debug("Fetching GitHub Action metadata")
+
+		body, err = forge.File(fmt.Sprintf("%s/%s", owner, repo), filePath, ref)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch action.yml: %w", err)
+	}
+
+	var metadata GitHubActionMetadata
+	if err := yaml.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse action.yml: %w", err)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, &metadata)
+
+	return &metadata, nil
+}
+
+// addRuntimeInfo reports the runtime of an action at its current and latest refs, flags
+// deprecated Node runtimes and lists the actions used by composite actions
+func (h *GitHubActionsHandler) addRuntimeInfo(result *GitHubActionVersion, forge Forge, owner, repo, path string) {
+	if result.CurrentVersion != nil {
+		if metadata, err := h.getActionMetadata(forge, owner, repo, path, *result.CurrentVersion); err == nil {
+			result.CurrentRuntime = StringPtr(metadata.Runs.Using)
+			result.DeprecatedRuntime = h.deprecatedRuntimes[metadata.Runs.Using]
+		} else {
+			h.logger.WithFields(logrus.Fields{
+				"owner": owner,
+				"repo":  repo,
+				"error": err.Error(),
+			}).Warn("Failed to get current GitHub Action metadata")
+		}
+	}
+
+	if result.LatestVersion == "" || result.LatestVersion == "unknown" {
+		return
+	}
+	metadata, err := h.getActionMetadata(forge, owner, repo, path, result.LatestVersion)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"owner": owner,
+			"repo":  repo,
+			"error": err.Error(),
+		}).Warn("Failed to get latest GitHub Action metadata")
+		return
+	}
+	result.LatestRuntime = StringPtr(metadata.Runs.Using)
+	result.LatestDeprecatedRuntime = h.deprecatedRuntimes[metadata.Runs.Using]
+	result.CompositeUses = h.compositeDependencies(forge, metadata, 1, map[string]bool{})
+}
+
+// compositeDependencies lists the actions used by the steps of a composite action,
+// following nested composite actions up to compositeActionMaxDepth levels deep
+func (h *GitHubActionsHandler) compositeDependencies(forge Forge, metadata *GitHubActionMetadata, depth int, seen map[string]bool) []GitHubActionDependency {
+	if metadata.Runs.Using != "composite" {
+		return nil
+	}
+
+	var dependencies []GitHubActionDependency
+	for _, step := range metadata.Runs.Steps {
+		if step.Uses == "" {
+			continue
+		}
+		dependency := GitHubActionDependency{Uses: step.Uses}
+
+		use, skipReason := parseActionUse(step.Uses)
+		if skipReason == "" && !seen[step.Uses] {
+			seen[step.Uses] = true
+			nestedForge, err := h.forgeForUse(forge, use)
+			var nested *GitHubActionMetadata
+			if err == nil {
+				nested, err = h.getActionMetadata(nestedForge, use.Owner, use.Repo, use.Path, use.Ref)
+			}
+			if err == nil {
+				dependency.Runtime = nested.Runs.Using
+				dependency.DeprecatedRuntime = h.deprecatedRuntimes[nested.Runs.Using]
+				if depth < compositeActionMaxDepth {
+					dependency.CompositeUses = h.compositeDependencies(nestedForge, nested, depth+1, seen)
+				}
+			} else {
+				h.logger.WithFields(logrus.Fields{
+					"uses":  step.Uses,
+					"error": err.Error(),
+				}).Warn("Failed to get nested GitHub Action metadata")
+			}
+		}
+
+		dependencies = append(dependencies, dependency)
+	}
+
+	return dependencies
+}
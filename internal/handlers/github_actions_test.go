@@ -0,0 +1,532 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWorkflow = `name: CI
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+      - uses: github/codeql-action/init@v2
+      - uses: ./.github/actions/setup
+      - uses: docker://alpine:3.19
+      - run: echo done
+      - uses: actions/checkout@v3
+  release:
+    uses: octo-org/workflows/.github/workflows/release.yml@v1
+`
+
+func TestParseActionUse(t *testing.T) {
+	use, skipReason := parseActionUse("github/codeql-action/init@v3")
+	assert.Empty(t, skipReason)
+	assert.Equal(t, GitHubActionUse{Reference: "github/codeql-action/init@v3", Owner: "github", Repo: "codeql-action", Path: "init", Ref: "v3"}, use)
+
+	_, skipReason = parseActionUse("docker://alpine:3.19")
+	assert.Equal(t, "Docker container action", skipReason)
+	_, skipReason = parseActionUse("./.github/actions/setup")
+	assert.Equal(t, "Local action", skipReason)
+	_, skipReason = parseActionUse("actions/checkout")
+	assert.NotEmpty(t, skipReason)
+}
+
+func TestGitHubActionsHandler_Workflow(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("repos/actions/checkout/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v5.0.0-beta", "prerelease": true}, {"tag_name": "v4.2.2"}]`,
+	})
+	mockClient.AddMockResponse("repos/github/codeql-action/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v3.28.0"}]`,
+	})
+	mockClient.AddMockResponse("repos/octo-org/workflows/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v2.0.0"}]`,
+	})
+
+	handler := NewGitHubActionsHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"workflow": testWorkflow,
+		"uses":     []interface{}{"github/codeql-action/analyze@v2"},
+	})
+	require.NoError(t, err)
+
+	var results map[string]GitHubActionReference
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 6)
+
+	checkout := results["actions/checkout@v3"]
+	assert.Equal(t, "v4.2.2", checkout.LatestVersion)
+	require.NotNil(t, checkout.CurrentVersion)
+	assert.Equal(t, "v3", *checkout.CurrentVersion)
+	assert.Equal(t, []string{"jobs.build.steps[0].uses", "jobs.build.steps[5].uses"}, checkout.Locations)
+
+	codeql := results["github/codeql-action/init@v2"]
+	assert.Equal(t, "codeql-action", codeql.Repo)
+	assert.Equal(t, "init", codeql.Path)
+	assert.Equal(t, "v3.28.0", codeql.LatestVersion)
+	assert.Equal(t, "v3.28.0", results["github/codeql-action/analyze@v2"].LatestVersion)
+
+	release := results["octo-org/workflows/.github/workflows/release.yml@v1"]
+	assert.True(t, release.ReusableWorkflow)
+	assert.Equal(t, "v2.0.0", release.LatestVersion)
+	assert.Equal(t, []string{"jobs.release.uses"}, release.Locations)
+
+	assert.True(t, results["./.github/actions/setup"].Skipped)
+	assert.Equal(t, "Docker container action", results["docker://alpine:3.19"].SkipReason)
+}
+
+func TestGitHubActionsHandler_PinSHA(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	latestSHA := "1111111111111111111111111111111111111111"
+	pinnedSHA := "2222222222222222222222222222222222222222"
+
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("repos/actions/checkout/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v4.2.2"}]`,
+	})
+	// v4.2.2 is an annotated tag, so the tag object must be dereferenced
+	mockClient.AddMockResponse("repos/actions/checkout/git/ref/tags/v4.2.2", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `{"ref": "refs/tags/v4.2.2", "object": {"type": "tag", "sha": "annotated"}}`,
+	})
+	mockClient.AddMockResponse("repos/actions/checkout/git/tags/annotated", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `{"object": {"type": "commit", "sha": "` + latestSHA + `"}}`,
+	})
+	mockClient.AddMockResponse("repos/actions/checkout/tags?per_page=100", tests.MockResponse{
+		StatusCode: 200,
+		Body: `[
+			{"name": "v4.2.2", "commit": {"sha": "` + latestSHA + `"}},
+			{"name": "v4.1.0", "commit": {"sha": "` + pinnedSHA + `"}},
+			{"name": "v4.1", "commit": {"sha": "` + pinnedSHA + `"}}
+		]`,
+	})
+
+	handler := NewGitHubActionsHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"uses":   []interface{}{"actions/checkout@" + pinnedSHA, "actions/checkout@3333333333333333333333333333333333333333"},
+		"pinSHA": true,
+	})
+	require.NoError(t, err)
+
+	var results map[string]GitHubActionReference
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 2)
+
+	pinned := results["actions/checkout@"+pinnedSHA]
+	require.NotNil(t, pinned.LatestSHA)
+	assert.Equal(t, latestSHA, *pinned.LatestSHA)
+	require.NotNil(t, pinned.PinnedUses)
+	assert.Equal(t, "uses: actions/checkout@"+latestSHA+" # v4.2.2", *pinned.PinnedUses)
+	require.NotNil(t, pinned.CurrentSHAVerified)
+	assert.True(t, *pinned.CurrentSHAVerified)
+	assert.Equal(t, []string{"v4.1.0", "v4.1"}, pinned.CurrentSHATags)
+
+	unknown := results["actions/checkout@3333333333333333333333333333333333333333"]
+	require.NotNil(t, unknown.CurrentSHAVerified)
+	assert.False(t, *unknown.CurrentSHAVerified)
+	assert.Empty(t, unknown.CurrentSHATags)
+}
+
+func TestGitHubActionsHandler_FloatingMajorTags(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	mockClient := tests.NewMockClient()
+	// Without releases, the newest exact tag is used rather than the first tag listed
+	mockClient.AddMockResponse("repos/actions/setup-node/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[]`,
+	})
+	mockClient.AddMockResponse("repos/actions/setup-node/tags?per_page=100", tests.MockResponse{
+		StatusCode: 200,
+		Body: `[
+			{"name": "v3", "commit": {"sha": "a"}},
+			{"name": "v3.9.1", "commit": {"sha": "a"}},
+			{"name": "v4", "commit": {"sha": "b"}},
+			{"name": "v4.1.0", "commit": {"sha": "b"}},
+			{"name": "v4.0.4", "commit": {"sha": "c"}},
+			{"name": "v5.0.0-beta.1", "commit": {"sha": "d"}}
+		]`,
+	})
+
+	handler := NewGitHubActionsHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"actions": []interface{}{
+			map[string]interface{}{"owner": "actions", "repo": "setup-node", "currentVersion": "v3"},
+		},
+		"includeTags": true,
+	})
+	require.NoError(t, err)
+
+	var results []GitHubActionVersion
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 1)
+	assert.Equal(t, "v4.1.0", results[0].LatestVersion)
+	require.NotNil(t, results[0].LatestExactTag)
+	assert.Equal(t, "v4.1.0", *results[0].LatestExactTag)
+	require.NotNil(t, results[0].LatestMajorTag)
+	assert.Equal(t, "v4", *results[0].LatestMajorTag)
+	assert.True(t, results[0].BehindMajor)
+
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"uses": []interface{}{"actions/setup-node@v3"},
+	})
+	require.NoError(t, err)
+
+	var references map[string]GitHubActionReference
+	unmarshalToolResult(t, result, &references)
+	// Tags aren't listed unless requested, but the latest release still shows the major bump
+	assert.True(t, references["actions/setup-node@v3"].BehindMajor)
+	assert.Nil(t, references["actions/setup-node@v3"].LatestMajorTag)
+	assert.Nil(t, references["actions/setup-node@v3"].LatestExactTag)
+}
+
+func TestGitHubActionsHandler_CheckRuntime(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("repos/acme/build/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v2.0.0"}]`,
+	})
+	mockClient.AddMockResponse("repos/acme/build/contents/action.yml?ref=v1.0.0", tests.MockResponse{
+		StatusCode: 200,
+		Body:       "runs:\n  using: node16\n  main: dist/index.js\n",
+	})
+	// The latest version is a composite action and only has an action.yaml
+	mockClient.AddMockResponse("repos/acme/build/contents/action.yaml?ref=v2.0.0", tests.MockResponse{
+		StatusCode: 200,
+		Body: `runs:
+  using: composite
+  steps:
+    - uses: acme/legacy@v1
+    - run: echo building
+      shell: bash
+    - uses: acme/setup/tool@v3
+`,
+	})
+	mockClient.AddMockResponse("repos/acme/legacy/contents/action.yml?ref=v1", tests.MockResponse{
+		StatusCode: 200,
+		Body:       "runs:\n  using: node12\n  main: index.js\n",
+	})
+	mockClient.AddMockResponse("repos/acme/setup/contents/tool/action.yml?ref=v3", tests.MockResponse{
+		StatusCode: 200,
+		Body:       "runs:\n  using: composite\n  steps:\n    - uses: actions/setup-node@v4\n",
+	})
+	mockClient.AddMockResponse("repos/actions/setup-node/contents/action.yml?ref=v4", tests.MockResponse{
+		StatusCode: 200,
+		Body:       "runs:\n  using: node20\n  main: dist/setup/index.js\n",
+	})
+
+	handler := NewGitHubActionsHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"actions": []interface{}{
+			map[string]interface{}{"owner": "acme", "repo": "build", "currentVersion": "v1.0.0"},
+		},
+		"checkRuntime": true,
+	})
+	require.NoError(t, err)
+
+	var results []GitHubActionVersion
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 1)
+	action := results[0]
+	assert.Equal(t, "v2.0.0", action.LatestVersion)
+	require.NotNil(t, action.CurrentRuntime)
+	assert.Equal(t, "node16", *action.CurrentRuntime)
+	assert.True(t, action.DeprecatedRuntime)
+	require.NotNil(t, action.LatestRuntime)
+	assert.Equal(t, "composite", *action.LatestRuntime)
+	assert.False(t, action.LatestDeprecatedRuntime)
+
+	require.Len(t, action.CompositeUses, 2)
+	assert.Equal(t, GitHubActionDependency{Uses: "acme/legacy@v1", Runtime: "node12", DeprecatedRuntime: true}, action.CompositeUses[0])
+	nested := action.CompositeUses[1]
+	assert.Equal(t, "acme/setup/tool@v3", nested.Uses)
+	assert.Equal(t, "composite", nested.Runtime)
+	assert.Equal(t, []GitHubActionDependency{{Uses: "actions/setup-node@v4", Runtime: "node20", DeprecatedRuntime: true}}, nested.CompositeUses)
+
+	// The deprecated runtimes can be replaced through the environment
+	t.Setenv("GITHUB_ACTIONS_DEPRECATED_RUNTIMES", "node16, Node20")
+	assert.Equal(t, map[string]bool{"node16": true, "node20": true}, deprecatedRuntimesFromEnv())
+	t.Setenv("GITHUB_ACTIONS_DEPRECATED_RUNTIMES", "")
+	assert.Empty(t, deprecatedRuntimesFromEnv())
+}
+
+func TestGitHubActionsHandler_SelfHostedForges(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("ghe.example.com/api/v3/repos/platform/deploy/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v3.0.0", "html_url": "https://ghe.example.com/platform/deploy/releases/tag/v3.0.0"}]`,
+	})
+	mockClient.AddMockResponse("code.forgejo.org/api/v1/repos/actions/checkout/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v4.1.0"}]`,
+	})
+	mockClient.AddMockResponse("code.forgejo.org/api/v1/repos/actions/checkout/tags/v4.1.0", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `{"name": "v4.1.0", "commit": {"sha": "4444444444444444444444444444444444444444"}}`,
+	})
+
+	handler := NewGitHubActionsHandler(logger, &sync.Map{})
+	handler.client = mockClient
+	handler.forges["ghe.example.com"] = ForgeConfig{Type: ForgeGitHub, Host: "ghe.example.com", APIURL: "https://ghe.example.com/api/v3"}
+	handler.forges["code.forgejo.org"] = ForgeConfig{Type: ForgeGitea, Host: "code.forgejo.org", APIURL: "https://code.forgejo.org/api/v1"}
+
+	// References without a host resolve against the configured host, while full URLs
+	// name their own forge
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"host":   "ghe.example.com",
+		"uses":   []interface{}{"platform/deploy@v2", "https://code.forgejo.org/actions/checkout@v3"},
+		"pinSHA": true,
+	})
+	require.NoError(t, err)
+
+	var results map[string]GitHubActionReference
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 2)
+
+	deploy := results["platform/deploy@v2"]
+	assert.Equal(t, "ghe.example.com", deploy.Host)
+	assert.Equal(t, "v3.0.0", deploy.LatestVersion)
+
+	checkout := results["https://code.forgejo.org/actions/checkout@v3"]
+	assert.Equal(t, "code.forgejo.org", checkout.Host)
+	assert.Equal(t, "actions", checkout.Owner)
+	assert.Equal(t, "checkout", checkout.Repo)
+	assert.Equal(t, "v4.1.0", checkout.LatestVersion)
+	require.NotNil(t, checkout.PinnedUses)
+	assert.Equal(t, "uses: https://code.forgejo.org/actions/checkout@4444444444444444444444444444444444444444 # v4.1.0", *checkout.PinnedUses)
+
+	_, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"host": "git.unknown.example.com",
+		"uses": []interface{}{"platform/deploy@v2"},
+	})
+	assert.Error(t, err)
+}
+
+func TestGitHubActionsHandler_RateLimit(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	handler := NewGitHubActionsHandler(logger, &sync.Map{})
+	handler.client = server.Client()
+	handler.forges[DefaultForgeHost] = ForgeConfig{Type: ForgeGitHub, Host: DefaultForgeHost, APIURL: server.URL, Token: "test-token"}
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"uses": []interface{}{"actions/checkout@v3"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	var rateLimit struct {
+		Error     string         `json:"error"`
+		RateLimit RateLimitError `json:"rateLimit"`
+	}
+	unmarshalToolResult(t, result, &rateLimit)
+	assert.Contains(t, rateLimit.Error, "rate limit exceeded")
+	require.NotNil(t, rateLimit.RateLimit.ResetAt)
+	assert.True(t, rateLimit.RateLimit.ResetAt.After(time.Now()))
+
+	// Further requests fail fast until the rate limit resets
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"actions": []interface{}{
+			map[string]interface{}{"owner": "actions", "repo": "setup-go"},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, 1, requests)
+}
+
+func TestGitHubActionsHandler_GraphQLBatching(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	graphQLRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The whole workflow is answered by one GraphQL query, and missing repositories
+		// fall back to REST
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/graphql":
+			graphQLRequests++
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			var request struct {
+				Query     string            `json:"query"`
+				Variables map[string]string `json:"variables"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Contains(t, request.Query, "r2: repository(owner: $owner2, name: $name2)")
+			assert.Equal(t, map[string]string{
+				"owner0": "actions", "name0": "checkout",
+				"owner1": "actions", "name1": "setup-go",
+				"owner2": "octo-org", "name2": "missing",
+			}, request.Variables)
+			_, _ = w.Write([]byte(`{
+				"data": {
+					"r0": {
+						"nameWithOwner": "actions/checkout",
+						"defaultBranchRef": {"name": "main"},
+						"releases": {"nodes": [{"tagName": "v4.2.2", "url": "https://github.com/actions/checkout/releases/tag/v4.2.2"}]},
+						"refs": {"pageInfo": {"hasNextPage": false}, "nodes": [
+							{"name": "v4.2.2", "target": {"oid": "tagobject", "target": {"oid": "1111111111111111111111111111111111111111"}}},
+							{"name": "v4", "target": {"oid": "1111111111111111111111111111111111111111"}}
+						]}
+					},
+					"r1": {
+						"nameWithOwner": "actions/setup-go",
+						"isArchived": true,
+						"defaultBranchRef": {"name": "main"},
+						"releases": {"nodes": [{"tagName": "v5.0.0-beta.1", "isPrerelease": true}, {"tagName": "v5.4.0"}]},
+						"refs": {"pageInfo": {"hasNextPage": false}, "nodes": [
+							{"name": "v5.4.0", "target": {"oid": "2222222222222222222222222222222222222222"}}
+						]}
+					},
+					"r2": null
+				},
+				"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository with the name 'octo-org/missing'."}]
+			}`))
+		case r.URL.Path == "/repos/octo-org/missing/releases":
+			http.NotFound(w, r)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	handler := NewGitHubActionsHandler(logger, &sync.Map{})
+	handler.client = server.Client()
+	handler.forges[DefaultForgeHost] = ForgeConfig{Type: ForgeGitHub, Host: DefaultForgeHost, APIURL: server.URL, Token: "test-token"}
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"uses":   []interface{}{"actions/checkout@v3", "actions/setup-go@v4", "octo-org/missing@v1", "actions/checkout@v3"},
+		"pinSHA": true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, graphQLRequests)
+
+	var results map[string]GitHubActionReference
+	unmarshalToolResult(t, result, &results)
+
+	checkout := results["actions/checkout@v3"]
+	assert.Equal(t, "v4.2.2", checkout.LatestVersion)
+	assert.True(t, checkout.BehindMajor)
+	require.NotNil(t, checkout.DefaultBranch)
+	assert.Equal(t, "main", *checkout.DefaultBranch)
+	require.NotNil(t, checkout.LatestSHA)
+	assert.Equal(t, "1111111111111111111111111111111111111111", *checkout.LatestSHA)
+
+	setupGo := results["actions/setup-go@v4"]
+	assert.Equal(t, "v5.4.0", setupGo.LatestVersion)
+	assert.True(t, setupGo.Archived)
+
+	assert.True(t, results["octo-org/missing@v1"].Skipped)
+}
+
+func TestGitHubActionsHandler_GraphQLRateLimited(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	resetAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	graphQLRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// An exhausted GraphQL rate limit falls back to the REST API, which has its own limit
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/graphql":
+			graphQLRequests++
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+			_, _ = w.Write([]byte(`{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded for user ID 1."}]}`))
+		case r.URL.Path == "/repos/actions/checkout/releases":
+			_, _ = w.Write([]byte(`[{"tag_name": "v4.2.2"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cache := &sync.Map{}
+	handler := NewGitHubActionsHandler(logger, cache)
+	handler.client = server.Client()
+	handler.forges[DefaultForgeHost] = ForgeConfig{Type: ForgeGitHub, Host: DefaultForgeHost, APIURL: server.URL, Token: "test-token"}
+
+	for _, reference := range []string{"actions/checkout@v3", "actions/checkout@v4"} {
+		result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+			"uses": []interface{}{reference},
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var results map[string]GitHubActionReference
+		unmarshalToolResult(t, result, &results)
+		assert.Equal(t, "v4.2.2", results[reference].LatestVersion)
+	}
+
+	// The GraphQL rate limit is remembered until it resets, without blocking REST requests
+	assert.Equal(t, 1, graphQLRequests)
+	cachedErr, ok := cache.Load("forge-rate-limit:github.com/graphql")
+	require.True(t, ok)
+	rateLimitErr := cachedErr.(*RateLimitError)
+	require.NotNil(t, rateLimitErr.ResetAt)
+	assert.Equal(t, resetAt, *rateLimitErr.ResetAt)
+	assert.Equal(t, 0, *rateLimitErr.Remaining)
+	_, ok = cache.Load("forge-rate-limit:github.com")
+	assert.False(t, ok)
+}
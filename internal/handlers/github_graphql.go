@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// githubGraphQLBatchSize is the number of repositories loaded by each GraphQL query
+const githubGraphQLBatchSize = 25
+
+// githubRepositoryFragment selects the releases, tags and metadata of a repository
+const githubRepositoryFragment = `fragment RepositoryVersions on Repository {
+  nameWithOwner
+  isArchived
+  defaultBranchRef { name }
+  releases(first: 30, orderBy: {field: CREATED_AT, direction: DESC}) {
+    nodes { tagName name publishedAt isDraft isPrerelease url }
+  }
+  refs(refPrefix: "refs/tags/", first: 100, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+    pageInfo { hasNextPage }
+    nodes { name target { oid ... on Tag { target { oid } } } }
+  }
+}`
+
+// GitHubGraphQLRepository represents a repository loaded with githubRepositoryFragment
+type GitHubGraphQLRepository struct {
+	NameWithOwner    string `json:"nameWithOwner"`
+	IsArchived       bool   `json:"isArchived"`
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	Releases struct {
+		Nodes []struct {
+			TagName      string `json:"tagName"`
+			Name         string `json:"name"`
+			PublishedAt  string `json:"publishedAt"`
+			IsDraft      bool   `json:"isDraft"`
+			IsPrerelease bool   `json:"isPrerelease"`
+			URL          string `json:"url"`
+		} `json:"nodes"`
+	} `json:"releases"`
+	Refs struct {
+		PageInfo struct {
+			HasNextPage bool `json:"hasNextPage"`
+		} `json:"pageInfo"`
+		Nodes []struct {
+			Name   string `json:"name"`
+			Target struct {
+				OID    string `json:"oid"`
+				Target *struct {
+					OID string `json:"oid"`
+				} `json:"target"`
+			} `json:"target"`
+		} `json:"nodes"`
+	} `json:"refs"`
+}
+
+// GitHubGraphQLResponse represents a response from the GitHub GraphQL API
+type GitHubGraphQLResponse struct {
+	Data   map[string]*GitHubGraphQLRepository `json:"data"`
+	Errors []struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQLURL returns the GraphQL endpoint, which GitHub Enterprise Server serves at
+// /api/graphql alongside the /api/v3 REST API
+func (f *gitHubForge) graphQLURL() string {
+	if base, ok := strings.CutSuffix(f.config.APIURL, "/v3"); ok {
+		return base + "/graphql"
+	}
+	return f.config.APIURL + "/graphql"
+}
+
+// graphQLRateLimitCacheKey is the cache key of an exhausted GraphQL rate limit, which is
+// tracked apart from the REST rate limit
+func (f *gitHubForge) graphQLRateLimitCacheKey() string {
+	return fmt.Sprintf("forge-rate-limit:%s/graphql", f.config.Host)
+}
+
+// Prefetch loads the latest releases, tags and metadata of many repositories with batched
+// GraphQL queries, so later Releases, Tags and TagCommit calls are answered from the cache.
+// The GraphQL API requires authentication, so nothing is loaded without a token.
+func (f *gitHubForge) Prefetch(projects []string) error {
+	if f.config.Token == "" {
+		return nil
+	}
+
+	// GraphQL has its own rate limit, so while it's exhausted the REST API is used instead
+	if cachedErr, ok := f.cache.Load(f.graphQLRateLimitCacheKey()); ok {
+		rateLimitErr := cachedErr.(*RateLimitError)
+		if rateLimitErr.ResetAt != nil && time.Now().Before(*rateLimitErr.ResetAt) {
+			return fmt.Errorf("GitHub GraphQL %s", rateLimitErr.Error())
+		}
+		f.cache.Delete(f.graphQLRateLimitCacheKey())
+	}
+
+	// Skip duplicates and repositories that were already loaded
+	seen := make(map[string]bool)
+	var pending []string
+	for _, project := range projects {
+		owner, name, ok := strings.Cut(project, "/")
+		if !ok || strings.Contains(name, "/") || seen[project] || f.Repository(project) != nil {
+			continue
+		}
+		seen[project] = true
+		pending = append(pending, owner+"/"+name)
+	}
+
+	for start := 0; start < len(pending); start += githubGraphQLBatchSize {
+		end := start + githubGraphQLBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if err := f.prefetchBatch(pending[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prefetchBatch loads a batch of repositories with a single GraphQL query
+func (f *gitHubForge) prefetchBatch(projects []string) error {
+	params := make([]string, 0, len(projects))
+	fields := make([]string, 0, len(projects))
+	variables := make(map[string]string, 2*len(projects))
+	for i, project := range projects {
+		owner, name, _ := strings.Cut(project, "/")
+		params = append(params, fmt.Sprintf("$owner%d: String!, $name%d: String!", i, i))
+		fields = append(fields, fmt.Sprintf("  r%d: repository(owner: $owner%d, name: $name%d) { ...RepositoryVersions }", i, i, i))
+		variables[fmt.Sprintf("owner%d", i)] = owner
+		variables[fmt.Sprintf("name%d", i)] = name
+	}
+	query := fmt.Sprintf("query(%s) {\n%s\n}\n%s", strings.Join(params, ", "), strings.Join(fields, "\n"), githubRepositoryFragment)
+
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode GraphQL query: %w", err)
+	}
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + f.config.Token,
+		"Content-Type":  "application/json",
+	}
+	body, header, err := f.do("POST", f.graphQLURL(), headers, requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to query GitHub GraphQL API: %w", err)
+	}
+
+	var response GitHubGraphQLResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse GitHub GraphQL response: %w", err)
+	}
+
+	// Missing repositories are reported as NOT_FOUND errors and left to the REST fallback.
+	// An exhausted GraphQL rate limit isn't returned as a RateLimitError, as the REST API
+	// has a separate limit the lookups can fall back to.
+	for _, graphQLErr := range response.Errors {
+		if graphQLErr.Type == "RATE_LIMITED" {
+			rateLimitErr := newRateLimitError(f.config.Host, http.StatusOK, header)
+			f.cache.Store(f.graphQLRateLimitCacheKey(), rateLimitErr)
+			return fmt.Errorf("GitHub GraphQL %s", rateLimitErr.Error())
+		}
+		f.logger.WithField("error", graphQLErr.Message).Debug("GitHub GraphQL query reported an error")
+	}
+
+	for i, project := range projects {
+		repository := response.Data[fmt.Sprintf("r%d", i)]
+		if repository == nil {
+			continue
+		}
+		f.storeRepository(project, repository)
+	}
+	return nil
+}
+
+// storeRepository caches the releases, tags and metadata of a repository loaded by GraphQL
+func (f *gitHubForge) storeRepository(project string, repository *GitHubGraphQLRepository) {
+	releases := make([]ForgeRelease, 0, len(repository.Releases.Nodes))
+	for _, release := range repository.Releases.Nodes {
+		releases = append(releases, ForgeRelease{
+			TagName:     release.TagName,
+			Name:        release.Name,
+			PublishedAt: release.PublishedAt,
+			Draft:       release.IsDraft,
+			Prerelease:  release.IsPrerelease,
+			URL:         release.URL,
+		})
+	}
+	f.cache.Store(fmt.Sprintf("forge-releases:%s/%s", f.config.Host, project), releases)
+
+	// Repositories with more tags than one page are listed through the REST API instead
+	if !repository.Refs.PageInfo.HasNextPage {
+		tags := make([]ForgeTag, 0, len(repository.Refs.Nodes))
+		for _, ref := range repository.Refs.Nodes {
+			// Annotated tags point to a tag object, which points to the commit
+			sha := ref.Target.OID
+			if ref.Target.Target != nil {
+				sha = ref.Target.Target.OID
+			}
+			tags = append(tags, ForgeTag{Name: ref.Name, CommitSHA: sha})
+		}
+		f.cache.Store(fmt.Sprintf("forge-tags:%s/%s", f.config.Host, project), tags)
+	}
+
+	metadata := &ForgeRepository{Archived: repository.IsArchived}
+	if repository.DefaultBranchRef != nil {
+		metadata.DefaultBranch = repository.DefaultBranchRef.Name
+	}
+	f.cache.Store(fmt.Sprintf("forge-repository:%s/%s", f.config.Host, project), metadata)
+}
+
+// Repository returns the metadata of a prefetched repository, or nil if it wasn't loaded
+func (f *gitHubForge) Repository(project string) *ForgeRepository {
+	if metadata, ok := f.cache.Load(fmt.Sprintf("forge-repository:%s/%s", f.config.Host, project)); ok {
+		return metadata.(*ForgeRepository)
+	}
+	return nil
+}
+
+// HasTags reports whether every tag of a repository was loaded by a batched query
+func (f *gitHubForge) HasTags(project string) bool {
+	_, ok := f.prefetchedTags(project)
+	return ok
+}
+
+// prefetchedReleases returns the releases of a prefetched repository
+func (f *gitHubForge) prefetchedReleases(project string) ([]ForgeRelease, bool) {
+	if releases, ok := f.cache.Load(fmt.Sprintf("forge-releases:%s/%s", f.config.Host, project)); ok {
+		return releases.([]ForgeRelease), true
+	}
+	return nil, false
+}
+
+// prefetchedTags returns the tags of a prefetched repository
+func (f *gitHubForge) prefetchedTags(project string) ([]ForgeTag, bool) {
+	if tags, ok := f.cache.Load(fmt.Sprintf("forge-tags:%s/%s", f.config.Host, project)); ok {
+		return tags.([]ForgeTag), true
+	}
+	return nil, false
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// HelmHandler handles Helm chart version checking
+type HelmHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+	docker *DockerHandler
+}
+
+// NewHelmHandler creates a new Helm handler
+func NewHelmHandler(logger *logrus.Logger, cache *sync.Map) *HelmHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &HelmHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+		docker: NewDockerHandler(logger, cache),
+	}
+}
+
+// HelmChartVersionEntry represents a single chart version in a repository index.yaml
+type HelmChartVersionEntry struct {
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+	Deprecated bool   `yaml:"deprecated"`
+}
+
+// HelmRepositoryIndex represents a Helm chart repository index.yaml file
+type HelmRepositoryIndex struct {
+	Entries map[string][]HelmChartVersionEntry `yaml:"entries"`
+}
+
+// getRepositoryIndex gets and parses the index.yaml of a chart repository
+func (h *HelmHandler) getRepositoryIndex(repository string) (*HelmRepositoryIndex, error) {
+	repository = strings.TrimSuffix(repository, "/")
+
+	// Check cache first
+	cacheKey := fmt.Sprintf("helm-index:%s", repository)
+	if cachedIndex, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("repository", repository).Debug("Using cached Helm repository index")
+		return cachedIndex.(*HelmRepositoryIndex), nil
+	}
+
+	// Construct URL
+	indexURL := repository + "/index.yaml"
+	h.logger.WithFields(logrus.Fields{
+		"repository": repository,
+		"url":        indexURL,
+	}).Debug("Fetching Helm repository index")
+
+	// Make request
+	headers := map[string]string{
+		"Accept": "application/x-yaml, text/yaml, */*",
+	}
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", indexURL, headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Helm repository index: %w", err)
+	}
+
+	// Parse response
+	var index HelmRepositoryIndex
+	if err := yaml.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse Helm repository index: %w", err)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, &index)
+
+	return &index, nil
+}
+
+// HelmChartConfig represents the Chart.yaml fields stored in the config blob of an OCI chart
+type HelmChartConfig struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	AppVersion string `json:"appVersion"`
+	Deprecated bool   `json:"deprecated"`
+}
+
+// ociChartLocation splits an oci:// repository URL and chart name into the registry host
+// and the repository path of the chart
+func ociChartLocation(repository, chartName string) (registryHost, path string) {
+	reference := strings.Trim(strings.TrimPrefix(repository, "oci://"), "/")
+	registryHost, path, _ = strings.Cut(reference, "/")
+	if path != "" {
+		path += "/"
+	}
+	return registryHost, path + chartName
+}
+
+// getOCIChartVersions lists the tags of a chart stored in an OCI registry
+func (h *HelmHandler) getOCIChartVersions(repository, chartName string) ([]HelmChartVersionEntry, error) {
+	registryHost, path := ociChartLocation(repository, chartName)
+
+	tags, err := h.docker.getRegistryTags(registryHost, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OCI chart tags: %w", err)
+	}
+
+	// OCI tags can't contain "+", so Helm stores SemVer build metadata with "_"
+	entries := make([]HelmChartVersionEntry, 0, len(tags))
+	for _, tag := range tags {
+		entries = append(entries, HelmChartVersionEntry{
+			Version: strings.ReplaceAll(tag.Tag, "_", "+"),
+		})
+	}
+
+	return entries, nil
+}
+
+// getOCIChartConfig reads the Chart.yaml fields of a chart version from the config blob of
+// its OCI manifest
+func (h *HelmHandler) getOCIChartConfig(repository, chartName, version string) (*HelmChartConfig, error) {
+	registryHost, path := ociChartLocation(repository, chartName)
+	tag := strings.ReplaceAll(version, "+", "_")
+
+	// Check cache first
+	cacheKey := fmt.Sprintf("helm-oci-config:%s/%s:%s", registryHost, path, tag)
+	if cachedConfig, ok := h.cache.Load(cacheKey); ok {
+		return cachedConfig.(*HelmChartConfig), nil
+	}
+
+	// Docker Hub serves the OCI Distribution API from registry-1.docker.io
+	if registryForHost(registryHost) == "dockerhub" {
+		registryHost = "registry-1.docker.io"
+	}
+	client := newOCIRegistryClient(h.client, h.logger, registryHost, path, h.docker.credentials(registryHost))
+
+	manifest, _, err := client.getManifest(tag)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Config == nil {
+		return nil, fmt.Errorf("chart manifest has no config")
+	}
+	_, body, err := client.do("GET", fmt.Sprintf("/v2/%s/blobs/%s", path, manifest.Config.Digest), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chart config: %w", err)
+	}
+
+	var config HelmChartConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse chart config: %w", err)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, &config)
+
+	return &config, nil
+}
+
+// GetLatestVersion gets the latest versions of Helm chart dependencies
+func (h *HelmHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest Helm chart versions")
+
+	// Parse dependencies
+	depsRaw, ok := args["dependencies"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: dependencies")
+	}
+
+	// Convert to []HelmChartDependency
+	var deps []HelmChartDependency
+	if depsArr, ok := depsRaw.([]interface{}); ok {
+		for _, depRaw := range depsArr {
+			if depMap, ok := depRaw.(map[string]interface{}); ok {
+				var dep HelmChartDependency
+				if name, ok := depMap["name"].(string); ok && name != "" {
+					dep.Name = name
+				} else {
+					continue
+				}
+				if version, ok := depMap["version"].(string); ok {
+					dep.Version = version
+				}
+				if repository, ok := depMap["repository"].(string); ok {
+					dep.Repository = repository
+				}
+				deps = append(deps, dep)
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("invalid dependencies format: expected array")
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	// Process each dependency
+	results := make([]HelmChartVersion, 0, len(deps))
+	for _, dep := range deps {
+		results = append(results, h.processChart(dep, constraints))
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// processChart processes a single Chart.yaml dependency
+func (h *HelmHandler) processChart(dep HelmChartDependency, constraints VersionConstraints) HelmChartVersion {
+	h.logger.WithFields(logrus.Fields{
+		"chart":      dep.Name,
+		"version":    dep.Version,
+		"repository": dep.Repository,
+	}).Debug("Processing Helm chart")
+
+	// Check if chart should be excluded
+	if constraint, ok := constraints[dep.Name]; ok && constraint.ExcludePackage {
+		return HelmChartVersion{
+			PackageVersion: PackageVersion{
+				Name:       dep.Name,
+				Skipped:    true,
+				SkipReason: "Package excluded by constraints",
+			},
+		}
+	}
+
+	// Clean version string, using the lower bound of ranges such as ">=1.0.0 <2.0.0"
+	var currentVersion string
+	if fields := strings.Fields(dep.Version); len(fields) > 0 {
+		currentVersion = cleanVersionRequirement(fields[0])
+	}
+
+	// Get available chart versions
+	var entries []HelmChartVersionEntry
+	var err error
+	switch {
+	case dep.Repository == "":
+		err = fmt.Errorf("no repository specified")
+	case strings.HasPrefix(dep.Repository, "oci://"):
+		entries, err = h.getOCIChartVersions(dep.Repository, dep.Name)
+	case strings.HasPrefix(dep.Repository, "http://"), strings.HasPrefix(dep.Repository, "https://"):
+		var index *HelmRepositoryIndex
+		index, err = h.getRepositoryIndex(dep.Repository)
+		if err == nil {
+			entries = index.Entries[dep.Name]
+			if len(entries) == 0 {
+				err = fmt.Errorf("chart %s not found in repository index", dep.Name)
+			}
+		}
+	default:
+		// Local file:// charts and @alias/alias: references to locally configured repositories
+		return HelmChartVersion{
+			PackageVersion: PackageVersion{
+				Name:           dep.Name,
+				CurrentVersion: StringPtr(currentVersion),
+				Registry:       dep.Repository,
+				Skipped:        true,
+				SkipReason:     fmt.Sprintf("Unsupported repository reference: %s (use an http(s):// or oci:// URL)", dep.Repository),
+			},
+		}
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"chart": dep.Name,
+			"error": err.Error(),
+		}).Error("Failed to get Helm chart versions")
+		return HelmChartVersion{
+			PackageVersion: PackageVersion{
+				Name:           dep.Name,
+				CurrentVersion: StringPtr(currentVersion),
+				LatestVersion:  "unknown",
+				Registry:       dep.Repository,
+				Skipped:        true,
+				SkipReason:     fmt.Sprintf("Failed to fetch chart versions: %v", err),
+			},
+		}
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[dep.Name]; ok {
+		majorVersion = constraint.MajorVersion
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		versions = append(versions, entry.Version)
+	}
+
+	result := HelmChartVersion{
+		PackageVersion: PackageVersion{
+			Name:           dep.Name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  FindLatestVersion(versions, majorVersion),
+			Registry:       dep.Repository,
+		},
+	}
+	if result.LatestVersion == "" {
+		result.LatestVersion = "unknown"
+		result.Skipped = true
+		result.SkipReason = "No stable chart versions found"
+		return result
+	}
+
+	// Report the application version packaged by the latest chart
+	for _, entry := range entries {
+		if entry.Version == result.LatestVersion {
+			if entry.AppVersion != "" {
+				result.AppVersion = StringPtr(entry.AppVersion)
+			}
+			result.Deprecated = entry.Deprecated
+			break
+		}
+	}
+
+	// OCI tags don't carry chart metadata, so read it from the latest chart's config
+	if strings.HasPrefix(dep.Repository, "oci://") {
+		config, err := h.getOCIChartConfig(dep.Repository, dep.Name, result.LatestVersion)
+		if err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"chart":   dep.Name,
+				"version": result.LatestVersion,
+				"error":   err.Error(),
+			}).Warn("Failed to get OCI chart config")
+		} else {
+			if config.AppVersion != "" {
+				result.AppVersion = StringPtr(config.AppVersion)
+			}
+			result.Deprecated = config.Deprecated
+		}
+	}
+
+	return result
+}
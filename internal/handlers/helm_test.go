@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelmHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Stand-in chart repository
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/charts/index.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`apiVersion: v1
+entries:
+  postgresql:
+    - version: 13.0.0-rc.1
+      appVersion: 16.1.0
+    - version: 12.12.10
+      appVersion: 15.4.0
+    - version: 12.1.0
+      appVersion: 15.1.0
+  legacy:
+    - version: 1.0.0
+      appVersion: 0.9.0
+      deprecated: true
+`))
+	}))
+	defer server.Close()
+
+	handler := NewHelmHandler(logger, &sync.Map{})
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"name": "postgresql", "version": "~12.1.0", "repository": server.URL + "/charts/"},
+			map[string]interface{}{"name": "legacy", "version": "1.0.0", "repository": server.URL + "/charts"},
+			map[string]interface{}{"name": "common", "version": "2.x.x", "repository": "@bitnami"},
+		},
+	})
+	require.NoError(t, err)
+
+	var versions []HelmChartVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 3)
+
+	assert.Equal(t, "common", versions[0].Name)
+	assert.True(t, versions[0].Skipped)
+
+	assert.Equal(t, "legacy", versions[1].Name)
+	assert.True(t, versions[1].Deprecated)
+
+	assert.Equal(t, "postgresql", versions[2].Name)
+	assert.Equal(t, "12.1.0", *versions[2].CurrentVersion)
+	assert.Equal(t, "12.12.10", versions[2].LatestVersion)
+	require.NotNil(t, versions[2].AppVersion)
+	assert.Equal(t, "15.4.0", *versions[2].AppVersion)
+}
+
+func TestHelmHandler_OCIRegistry(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Stand-in OCI registry holding a chart, with build metadata stored as "_" in tags
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/charts/redis/tags/list":
+			_, _ = w.Write([]byte(`{"name": "charts/redis", "tags": ["18.0.0", "19.6.4_build.1", "20.0.0-rc.1"]}`))
+		case "/v2/charts/redis/manifests/19.6.4_build.1":
+			w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+			_, _ = w.Write([]byte(`{
+				"schemaVersion": 2,
+				"mediaType": "application/vnd.oci.image.manifest.v1+json",
+				"config": {"mediaType": "application/vnd.cncf.helm.config.v1+json", "digest": "sha256:config", "size": 60},
+				"layers": [{"mediaType": "application/vnd.cncf.helm.chart.content.v1.tar+gzip", "digest": "sha256:chart", "size": 1024}]
+			}`))
+		case "/v2/charts/redis/blobs/sha256:config":
+			_, _ = w.Write([]byte(`{"name": "redis", "version": "19.6.4+build.1", "appVersion": "7.2.5"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	handler := NewHelmHandler(logger, &sync.Map{})
+	handler.client = server.Client()
+	handler.docker.client = server.Client()
+	handler.docker.credentials = func(string) *RegistryCredentials { return nil }
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"name": "redis", "version": "18.0.0", "repository": "oci://" + strings.TrimPrefix(server.URL, "https://") + "/charts"},
+		},
+	})
+	require.NoError(t, err)
+
+	var versions []HelmChartVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 1)
+
+	assert.Equal(t, "redis", versions[0].Name)
+	assert.False(t, versions[0].Skipped, versions[0].SkipReason)
+	assert.Equal(t, "19.6.4+build.1", versions[0].LatestVersion)
+	require.NotNil(t, versions[0].AppVersion)
+	assert.Equal(t, "7.2.5", *versions[0].AppVersion)
+}
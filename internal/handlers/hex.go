@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// HexURL is the base URL for the hex.pm API
+	HexURL = "https://hex.pm/api"
+)
+
+var (
+	// mixDepTupleRegex matches a mix.exs dependency tuple such as {:phoenix, "~> 1.7", only: :dev}
+	mixDepTupleRegex = regexp.MustCompile(`^\{\s*:([a-zA-Z0-9_]+)\s*(?:,\s*"([^"]*)")?\s*(?:,\s*(.*?))?\s*\}$`)
+	// mixDepOptionRegex matches a single keyword option inside a mix.exs dependency tuple
+	mixDepOptionRegex = regexp.MustCompile(`([a-z_]+):\s*(\[[^\]]*\]|"[^"]*"|[^,]+)`)
+)
+
+// HexHandler handles Elixir/Erlang Hex package version checking
+type HexHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+}
+
+// NewHexHandler creates a new Hex handler
+func NewHexHandler(logger *logrus.Logger, cache *sync.Map) *HexHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &HexHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// HexPackageInfo represents information about a package from the hex.pm API
+type HexPackageInfo struct {
+	Name     string `json:"name"`
+	Releases []struct {
+		Version string `json:"version"`
+	} `json:"releases"`
+	Retirements map[string]HexRetirement `json:"retirements"`
+}
+
+// getPackageInfo gets information about a Hex package
+func (h *HexHandler) getPackageInfo(packageName string) (*HexPackageInfo, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("hex:%s", packageName)
+	if cachedInfo, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("package", packageName).Debug("Using cached Hex package info")
+		return cachedInfo.(*HexPackageInfo), nil
+	}
+
+	// Construct URL
+	packageURL := fmt.Sprintf("%s/packages/%s", HexURL, url.PathEscape(packageName))
+	h.logger.WithFields(logrus.Fields{
+		"package": packageName,
+		"url":     packageURL,
+	}).Debug("Fetching Hex package info")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", packageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Hex package info: %w", err)
+	}
+
+	// Parse response
+	var info HexPackageInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse Hex package info: %w", err)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, &info)
+
+	return &info, nil
+}
+
+// parseMixDependencyTuple parses a mix.exs dependency tuple string into a MixDependency
+func parseMixDependencyTuple(tuple string) (MixDependency, error) {
+	matches := mixDepTupleRegex.FindStringSubmatch(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(tuple), ",")))
+	if matches == nil {
+		return MixDependency{}, fmt.Errorf("invalid mix dependency format: %s", tuple)
+	}
+
+	dep := MixDependency{
+		Name:        matches[1],
+		Requirement: matches[2],
+		Options:     make(map[string]string),
+	}
+	for _, option := range mixDepOptionRegex.FindAllStringSubmatch(matches[3], -1) {
+		dep.Options[option[1]] = strings.TrimSpace(option[2])
+	}
+
+	return dep, nil
+}
+
+// parseMixDependency converts a tool argument into a MixDependency. Entries may be
+// tuple strings or objects with name, requirement and options fields.
+func parseMixDependency(raw interface{}) (MixDependency, error) {
+	switch value := raw.(type) {
+	case string:
+		return parseMixDependencyTuple(value)
+	case map[string]interface{}:
+		var dep MixDependency
+		name, ok := value["name"].(string)
+		if !ok || name == "" {
+			return dep, fmt.Errorf("missing dependency name")
+		}
+		dep.Name = strings.TrimPrefix(name, ":")
+		if requirement, ok := value["requirement"].(string); ok {
+			dep.Requirement = requirement
+		}
+		dep.Options = make(map[string]string)
+		if options, ok := value["options"].(map[string]interface{}); ok {
+			for key, option := range options {
+				dep.Options[key] = fmt.Sprintf("%v", option)
+			}
+		}
+		return dep, nil
+	default:
+		return MixDependency{}, fmt.Errorf("invalid mix dependency format: %v", value)
+	}
+}
+
+// mixOptionValue normalises an Elixir option value such as :test, [:dev, :test] or "name"
+func mixOptionValue(value string) string {
+	value = strings.Trim(value, `[]"`)
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimPrefix(strings.TrimSpace(part), ":")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// GetLatestVersion gets the latest version of Hex packages from mix.exs
+func (h *HexHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest Hex package versions")
+
+	// Parse dependencies
+	depsRaw, ok := args["dependencies"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: dependencies")
+	}
+
+	depsArr, ok := depsRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid dependencies format: expected array")
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	// Process each dependency
+	results := make([]HexPackageVersion, 0, len(depsArr))
+	for _, depRaw := range depsArr {
+		dep, err := parseMixDependency(depRaw)
+		if err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"dependency": depRaw,
+				"error":      err.Error(),
+			}).Error("Failed to parse mix dependency")
+			results = append(results, HexPackageVersion{
+				PackageVersion: PackageVersion{
+					Name:       fmt.Sprintf("%v", depRaw),
+					Registry:   "hex",
+					Skipped:    true,
+					SkipReason: fmt.Sprintf("Failed to parse dependency: %v", err),
+				},
+			})
+			continue
+		}
+
+		result := h.processPackage(dep, constraints)
+		if only, ok := dep.Options["only"]; ok {
+			result.Name = fmt.Sprintf("%s (%s)", result.Name, mixOptionValue(only))
+		}
+		results = append(results, result)
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// processPackage processes a single mix dependency
+func (h *HexHandler) processPackage(dep MixDependency, constraints VersionConstraints) HexPackageVersion {
+	h.logger.WithFields(logrus.Fields{
+		"package":     dep.Name,
+		"requirement": dep.Requirement,
+	}).Debug("Processing Hex package")
+
+	// Check if package should be excluded
+	if constraint, ok := constraints[dep.Name]; ok && constraint.ExcludePackage {
+		return HexPackageVersion{
+			PackageVersion: PackageVersion{
+				Name:       dep.Name,
+				Skipped:    true,
+				SkipReason: "Package excluded by constraints",
+			},
+		}
+	}
+
+	// Dependencies that don't come from hex.pm can't be checked
+	for _, source := range []string{"path", "git", "github", "in_umbrella"} {
+		if value, ok := dep.Options[source]; ok {
+			return HexPackageVersion{
+				PackageVersion: PackageVersion{
+					Name:       dep.Name,
+					Registry:   "hex",
+					Skipped:    true,
+					SkipReason: fmt.Sprintf("Non-Hex dependency (%s: %s)", source, mixOptionValue(value)),
+				},
+			}
+		}
+	}
+
+	// The hex option overrides the package name on hex.pm
+	packageName := dep.Name
+	if hexName, ok := dep.Options["hex"]; ok {
+		packageName = mixOptionValue(hexName)
+	}
+
+	// Clean version string
+	currentVersion := cleanVersionRequirement(strings.Split(dep.Requirement, " or ")[0])
+
+	// Get package info
+	info, err := h.getPackageInfo(packageName)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"package": packageName,
+			"error":   err.Error(),
+		}).Error("Failed to get Hex package info")
+		return HexPackageVersion{
+			PackageVersion: PackageVersion{
+				Name:           dep.Name,
+				CurrentVersion: StringPtr(currentVersion),
+				LatestVersion:  "unknown",
+				Registry:       "hex",
+				Skipped:        true,
+				SkipReason:     fmt.Sprintf("Failed to fetch package info: %v", err),
+			},
+		}
+	}
+
+	// Retired releases should never be recommended
+	versions := make([]string, 0, len(info.Releases))
+	for _, release := range info.Releases {
+		if _, retired := info.Retirements[release.Version]; !retired {
+			versions = append(versions, release.Version)
+		}
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[dep.Name]; ok {
+		majorVersion = constraint.MajorVersion
+	}
+
+	result := HexPackageVersion{
+		PackageVersion: PackageVersion{
+			Name:           dep.Name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  FindLatestVersion(versions, majorVersion),
+			Registry:       "hex",
+		},
+	}
+
+	// Report whether the currently required version has been retired
+	if retirement, ok := info.Retirements[currentVersion]; ok {
+		result.Retired = &retirement
+	} else if major, minor, patch, err := ParseVersion(currentVersion); err == nil {
+		if retirement, ok := info.Retirements[fmt.Sprintf("%d.%d.%d", major, minor, patch)]; ok {
+			result.Retired = &retirement
+		}
+	}
+
+	if result.LatestVersion == "" {
+		result.LatestVersion = "unknown"
+		result.Skipped = true
+		result.SkipReason = "No stable, non-retired releases found"
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMixDependencyTuple(t *testing.T) {
+	dep, err := parseMixDependencyTuple(`{:ex_doc, "~> 0.31", only: [:dev, :test], runtime: false},`)
+	require.NoError(t, err)
+	assert.Equal(t, "ex_doc", dep.Name)
+	assert.Equal(t, "~> 0.31", dep.Requirement)
+	assert.Equal(t, "dev, test", mixOptionValue(dep.Options["only"]))
+	assert.Equal(t, "false", dep.Options["runtime"])
+
+	dep, err = parseMixDependencyTuple(`{:my_app, in_umbrella: true}`)
+	require.NoError(t, err)
+	assert.Equal(t, "my_app", dep.Name)
+	assert.Empty(t, dep.Requirement)
+	assert.Equal(t, "true", dep.Options["in_umbrella"])
+
+	_, err = parseMixDependencyTuple(`phoenix 1.7`)
+	assert.Error(t, err)
+}
+
+func TestHexHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Mock hex.pm response with a retired release
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("hex.pm/api/packages/plug", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"name": "plug", "releases": [
+			{"version": "1.16.1"},
+			{"version": "1.16.0"},
+			{"version": "1.15.0"},
+			{"version": "1.17.0-rc.0"}
+		], "retirements": {
+			"1.16.1": {"reason": "invalid", "message": "Broken release"},
+			"1.15.0": {"reason": "security", "message": "Use 1.15.1 or later"}
+		}}`,
+	})
+
+	handler := NewHexHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			`{:plug, "1.15.0", only: :test}`,
+			`{:local_dep, path: "../local_dep"}`,
+		},
+	})
+	require.NoError(t, err)
+
+	var versions []HexPackageVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 2)
+
+	assert.Equal(t, "local_dep", versions[0].Name)
+	assert.True(t, versions[0].Skipped)
+
+	assert.Equal(t, "plug (test)", versions[1].Name)
+	assert.Equal(t, "1.16.0", versions[1].LatestVersion)
+	require.NotNil(t, versions[1].Retired)
+	assert.Equal(t, "security", versions[1].Retired.Reason)
+}
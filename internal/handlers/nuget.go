@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// NuGetServiceIndexURL is the URL of the NuGet v3 service index
+	NuGetServiceIndexURL = "https://api.nuget.org/v3/index.json"
+)
+
+// NuGetHandler handles NuGet package version checking
+type NuGetHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+}
+
+// NewNuGetHandler creates a new NuGet handler
+func NewNuGetHandler(logger *logrus.Logger, cache *sync.Map) *NuGetHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &NuGetHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// NuGetServiceIndex represents the NuGet v3 service index
+type NuGetServiceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// NuGetRegistrationIndex represents a NuGet registration index or page
+type NuGetRegistrationIndex struct {
+	Items []NuGetRegistrationPage `json:"items"`
+}
+
+// NuGetRegistrationPage represents a page of registration leaves. Leaves are only
+// inlined for small packages, otherwise the page must be fetched from its @id.
+type NuGetRegistrationPage struct {
+	ID    string `json:"@id"`
+	Items []struct {
+		CatalogEntry struct {
+			ID      string `json:"id"`
+			Version string `json:"version"`
+			Listed  *bool  `json:"listed"`
+		} `json:"catalogEntry"`
+	} `json:"items"`
+}
+
+// NuGetPackageVersion represents a published version of a NuGet package
+type NuGetPackageVersion struct {
+	Version string
+	Listed  bool
+}
+
+// getRegistrationsBaseURL resolves the registration resource from the service index
+func (h *NuGetHandler) getRegistrationsBaseURL() (string, error) {
+	// Check cache first
+	cacheKey := "nuget:registrations-base-url"
+	if cachedURL, ok := h.cache.Load(cacheKey); ok {
+		return cachedURL.(string), nil
+	}
+
+	h.logger.WithField("url", NuGetServiceIndexURL).Debug("Fetching NuGet service index")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", NuGetServiceIndexURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch NuGet service index: %w", err)
+	}
+
+	// Parse response
+	var index NuGetServiceIndex
+	if err := json.Unmarshal(body, &index); err != nil {
+		return "", fmt.Errorf("failed to parse NuGet service index: %w", err)
+	}
+
+	// Prefer the SemVer 2.0.0 registration hive, falling back to any registration resource
+	var baseURL string
+	for _, resource := range index.Resources {
+		if resource.Type == "RegistrationsBaseUrl/3.6.0" {
+			baseURL = resource.ID
+			break
+		}
+		if baseURL == "" && strings.HasPrefix(resource.Type, "RegistrationsBaseUrl") {
+			baseURL = resource.ID
+		}
+	}
+	if baseURL == "" {
+		return "", fmt.Errorf("no registration resource found in NuGet service index")
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, baseURL)
+
+	return baseURL, nil
+}
+
+// getPackageVersions gets all published versions of a NuGet package
+func (h *NuGetHandler) getPackageVersions(packageID string) ([]NuGetPackageVersion, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("nuget:%s", strings.ToLower(packageID))
+	if cachedVersions, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("package", packageID).Debug("Using cached NuGet package versions")
+		return cachedVersions.([]NuGetPackageVersion), nil
+	}
+
+	baseURL, err := h.getRegistrationsBaseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Construct URL
+	indexURL := fmt.Sprintf("%s/%s/index.json", strings.TrimSuffix(baseURL, "/"), url.PathEscape(strings.ToLower(packageID)))
+	h.logger.WithFields(logrus.Fields{
+		"package": packageID,
+		"url":     indexURL,
+	}).Debug("Fetching NuGet registration index")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", indexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch NuGet registration index: %w", err)
+	}
+
+	// Parse response
+	var index NuGetRegistrationIndex
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse NuGet registration index: %w", err)
+	}
+
+	var versions []NuGetPackageVersion
+	for _, page := range index.Items {
+		// Fetch pages whose leaves are not inlined
+		if len(page.Items) == 0 && page.ID != "" {
+			body, err := MakeRequestWithLogger(h.client, h.logger, "GET", page.ID, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch NuGet registration page: %w", err)
+			}
+			if err := json.Unmarshal(body, &page); err != nil {
+				return nil, fmt.Errorf("failed to parse NuGet registration page: %w", err)
+			}
+		}
+
+		for _, leaf := range page.Items {
+			versions = append(versions, NuGetPackageVersion{
+				Version: leaf.CatalogEntry.Version,
+				Listed:  leaf.CatalogEntry.Listed == nil || *leaf.CatalogEntry.Listed,
+			})
+		}
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, versions)
+
+	return versions, nil
+}
+
+// cleanNuGetVersion extracts a comparable version from a NuGet version or version range
+// (e.g. "[1.2.0, 2.0.0)" becomes "1.2.0")
+func cleanNuGetVersion(version string) string {
+	version = strings.TrimLeft(strings.TrimSpace(version), "[(")
+	if idx := strings.Index(version, ","); idx != -1 {
+		version = version[:idx]
+	}
+	return strings.TrimSpace(strings.TrimRight(version, ")]"))
+}
+
+// GetLatestVersion gets the latest version of NuGet packages
+func (h *NuGetHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest NuGet package versions")
+
+	// Parse packages
+	packagesRaw, ok := args["packages"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: packages")
+	}
+
+	// Convert to []NuGetPackageReference
+	var packages []NuGetPackageReference
+	if packagesArr, ok := packagesRaw.([]interface{}); ok {
+		for _, packageRaw := range packagesArr {
+			if packageMap, ok := packageRaw.(map[string]interface{}); ok {
+				var pkg NuGetPackageReference
+				if id, ok := packageMap["id"].(string); ok && id != "" {
+					pkg.ID = id
+				} else {
+					continue
+				}
+				if version, ok := packageMap["version"].(string); ok {
+					pkg.Version = version
+				}
+				if targetFramework, ok := packageMap["targetFramework"].(string); ok {
+					pkg.TargetFramework = targetFramework
+				}
+				packages = append(packages, pkg)
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("invalid packages format: expected array")
+	}
+
+	// Parse include prerelease
+	includePrerelease := false
+	if includePrereleaseRaw, ok := args["includePrerelease"].(bool); ok {
+		includePrerelease = includePrereleaseRaw
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	// Process each package
+	results := make([]PackageVersion, 0, len(packages))
+	for _, pkg := range packages {
+		result := h.processPackage(pkg, constraints, includePrerelease)
+		if pkg.TargetFramework != "" {
+			result.Name = fmt.Sprintf("%s (%s)", result.Name, pkg.TargetFramework)
+		}
+		results = append(results, result)
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// processPackage processes a single NuGet package reference
+func (h *NuGetHandler) processPackage(pkg NuGetPackageReference, constraints VersionConstraints, includePrerelease bool) PackageVersion {
+	h.logger.WithFields(logrus.Fields{
+		"package": pkg.ID,
+		"version": pkg.Version,
+	}).Debug("Processing NuGet package")
+
+	// Check if package should be excluded
+	if constraint, ok := constraints[pkg.ID]; ok && constraint.ExcludePackage {
+		return PackageVersion{
+			Name:       pkg.ID,
+			Skipped:    true,
+			SkipReason: "Package excluded by constraints",
+		}
+	}
+
+	// Clean version string
+	currentVersion := cleanNuGetVersion(pkg.Version)
+
+	// Get package versions
+	packageVersions, err := h.getPackageVersions(pkg.ID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"package": pkg.ID,
+			"error":   err.Error(),
+		}).Error("Failed to get NuGet package versions")
+		return PackageVersion{
+			Name:           pkg.ID,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "nuget",
+			Skipped:        true,
+			SkipReason:     fmt.Sprintf("Failed to fetch package info: %v", err),
+		}
+	}
+
+	// Unlisted versions are hidden from search and should not be recommended
+	versions := make([]string, 0, len(packageVersions))
+	for _, version := range packageVersions {
+		if version.Listed {
+			versions = append(versions, version.Version)
+		}
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[pkg.ID]; ok {
+		majorVersion = constraint.MajorVersion
+	}
+
+	var latestVersion string
+	if includePrerelease {
+		latestVersion = FindLatestVersionWithPrereleases(versions, majorVersion)
+	} else {
+		latestVersion = FindLatestVersion(versions, majorVersion)
+	}
+
+	if latestVersion == "" {
+		return PackageVersion{
+			Name:           pkg.ID,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "nuget",
+			Skipped:        true,
+			SkipReason:     "No listed versions found",
+		}
+	}
+
+	return PackageVersion{
+		Name:           pkg.ID,
+		CurrentVersion: StringPtr(currentVersion),
+		LatestVersion:  latestVersion,
+		Registry:       "nuget",
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNuGetHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Mock the service index, a registration index with one remote page and the page itself
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("api.nuget.org/v3/index.json", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"resources": [
+			{"@id": "https://api.nuget.org/v3/registration5-semver1/", "@type": "RegistrationsBaseUrl"},
+			{"@id": "https://api.nuget.org/v3/registration5-gz-semver2/", "@type": "RegistrationsBaseUrl/3.6.0"}
+		]}`,
+	})
+	mockClient.AddMockResponse("registration5-gz-semver2/newtonsoft.json/index.json", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `{"items": [{"@id": "https://api.nuget.org/v3/registration5-gz-semver2/newtonsoft.json/page/1.0.0/14.0.0.json"}]}`,
+	})
+	mockClient.AddMockResponse("registration5-gz-semver2/newtonsoft.json/page/", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"items": [
+			{"catalogEntry": {"id": "Newtonsoft.Json", "version": "12.0.3", "listed": true}},
+			{"catalogEntry": {"id": "Newtonsoft.Json", "version": "13.0.3"}},
+			{"catalogEntry": {"id": "Newtonsoft.Json", "version": "13.0.4", "listed": false}},
+			{"catalogEntry": {"id": "Newtonsoft.Json", "version": "14.0.0-beta1", "listed": true}}
+		]}`,
+	})
+
+	handler := NewNuGetHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"packages": []interface{}{
+			map[string]interface{}{"id": "Newtonsoft.Json", "version": "[12.0.1, 14.0.0)"},
+		},
+	})
+	require.NoError(t, err)
+
+	var versions []PackageVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "12.0.1", *versions[0].CurrentVersion)
+	assert.Equal(t, "13.0.3", versions[0].LatestVersion)
+	assert.Equal(t, "nuget", versions[0].Registry)
+}
@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ociMaxTagPages limits how many pages of tags are followed for a single repository
+	ociMaxTagPages = 50
+	// ociManifestAccept lists the manifest media types accepted when resolving digests
+	ociManifestAccept = "application/vnd.oci.image.index.v1+json, " +
+		"application/vnd.docker.distribution.manifest.list.v2+json, " +
+		"application/vnd.oci.image.manifest.v1+json, " +
+		"application/vnd.docker.distribution.manifest.v2+json"
+)
+
+var (
+	// wwwAuthenticateParamRegex matches key="value" parameters in a WWW-Authenticate header
+	wwwAuthenticateParamRegex = regexp.MustCompile(`([a-zA-Z_]+)="([^"]*)"`)
+	// linkNextRegex matches the next page URL in a Link header
+	linkNextRegex = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+)
+
+// RegistryCredentials holds credentials used to authenticate against a container registry
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// OCITagsResponse represents a response from the OCI Distribution tags/list endpoint
+type OCITagsResponse struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// OCITokenResponse represents a response from a registry token service
+type OCITokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// OCIDescriptor represents a content descriptor in an OCI image index or manifest
+type OCIDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	Platform  *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+		Variant      string `json:"variant"`
+	} `json:"platform,omitempty"`
+}
+
+// OCIManifest represents an OCI image index, Docker manifest list or single image manifest
+type OCIManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []OCIDescriptor `json:"manifests"`
+	Config    *OCIDescriptor  `json:"config"`
+	Layers    []OCIDescriptor `json:"layers"`
+}
+
+// OCIImageConfig represents the platform fields of an image configuration blob
+type OCIImageConfig struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant"`
+}
+
+// ociRegistryClient talks to a single repository on a registry implementing the OCI
+// Distribution API, handling the bearer token challenge and pagination
+type ociRegistryClient struct {
+	client      HTTPClient
+	logger      *logrus.Logger
+	baseURL     string
+	repository  string
+	credentials *RegistryCredentials
+	// authorization is the Authorization header value obtained from the last challenge
+	authorization string
+}
+
+// newOCIRegistryClient creates a client for a repository. The registry may be given as a
+// bare host (https is assumed) or as a URL including the scheme.
+func newOCIRegistryClient(client HTTPClient, logger *logrus.Logger, registry, repository string, credentials *RegistryCredentials) *ociRegistryClient {
+	baseURL := strings.TrimSuffix(registry, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "https://" + baseURL
+	}
+	return &ociRegistryClient{
+		client:      client,
+		logger:      logger,
+		baseURL:     baseURL,
+		repository:  strings.Trim(repository, "/"),
+		credentials: credentials,
+	}
+}
+
+// registryHost returns the host part of a registry given as a host or URL
+func registryHost(registry string) string {
+	if parsed, err := url.Parse(registry); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return strings.TrimSuffix(registry, "/")
+}
+
+// parseWWWAuthenticate parses a WWW-Authenticate header into its scheme and parameters
+func parseWWWAuthenticate(header string) (scheme string, params map[string]string) {
+	params = make(map[string]string)
+	header = strings.TrimSpace(header)
+	if idx := strings.Index(header, " "); idx != -1 {
+		scheme = header[:idx]
+		for _, match := range wwwAuthenticateParamRegex.FindAllStringSubmatch(header[idx+1:], -1) {
+			params[strings.ToLower(match[1])] = match[2]
+		}
+	} else {
+		scheme = header
+	}
+	return strings.ToLower(scheme), params
+}
+
+// do sends a request to the registry, answering a single authentication challenge if needed
+func (c *ociRegistryClient) do(method, requestURL string, headers map[string]string) (*http.Response, []byte, error) {
+	if !strings.HasPrefix(requestURL, "http://") && !strings.HasPrefix(requestURL, "https://") {
+		requestURL = c.baseURL + "/" + strings.TrimPrefix(requestURL, "/")
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		c.logger.WithFields(logrus.Fields{
+			"method": method,
+			"url":    requestURL,
+		}).Debug("Making registry request")
+
+		req, err := http.NewRequest(method, requestURL, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		if req.Header.Get("User-Agent") == "" {
+			req.Header.Set("User-Agent", "mcp-package-version/1.0.0")
+		}
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to send request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			if err := c.authenticate(resp.Header.Get("WWW-Authenticate")); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return resp, nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+		}
+
+		return resp, body, nil
+	}
+
+	return nil, nil, fmt.Errorf("registry authentication failed for %s", requestURL)
+}
+
+// authenticate answers a WWW-Authenticate challenge, fetching a bearer token from the
+// registry's token service or falling back to basic authentication
+func (c *ociRegistryClient) authenticate(challenge string) error {
+	scheme, params := parseWWWAuthenticate(challenge)
+	switch scheme {
+	case "bearer":
+		token, err := c.fetchToken(params["realm"], params["service"], params["scope"])
+		if err != nil {
+			return err
+		}
+		c.authorization = "Bearer " + token
+		return nil
+	case "basic":
+		if c.credentials == nil {
+			return fmt.Errorf("registry requires basic authentication but no credentials are configured")
+		}
+		c.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(c.credentials.Username+":"+c.credentials.Password))
+		return nil
+	default:
+		return fmt.Errorf("unsupported registry authentication challenge: %q", challenge)
+	}
+}
+
+// fetchToken requests a pull token from a registry token service
+func (c *ociRegistryClient) fetchToken(realm, service, scope string) (string, error) {
+	if realm == "" {
+		return "", fmt.Errorf("registry token challenge is missing a realm")
+	}
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.repository)
+	}
+
+	query := url.Values{}
+	if service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", scope)
+	tokenURL := realm
+	if strings.Contains(realm, "?") {
+		tokenURL += "&" + query.Encode()
+	} else {
+		tokenURL += "?" + query.Encode()
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"realm":     realm,
+		"service":   service,
+		"scope":     scope,
+		"anonymous": c.credentials == nil,
+	}).Debug("Fetching registry token")
+
+	headers := map[string]string{}
+	if c.credentials != nil {
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(c.credentials.Username+":"+c.credentials.Password))
+	}
+	body, err := MakeRequestWithLogger(c.client, c.logger, "GET", tokenURL, headers)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch registry token: %w", err)
+	}
+
+	var response OCITokenResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if response.Token != "" {
+		return response.Token, nil
+	}
+	if response.AccessToken != "" {
+		return response.AccessToken, nil
+	}
+
+	return "", fmt.Errorf("registry token service returned no token")
+}
+
+// listTags lists all tags of the repository, following Link header pagination
+func (c *ociRegistryClient) listTags() ([]string, error) {
+	var tags []string
+	nextURL := fmt.Sprintf("/v2/%s/tags/list?n=1000", c.repository)
+
+	for page := 0; nextURL != "" && page < ociMaxTagPages; page++ {
+		resp, body, err := c.do("GET", nextURL, map[string]string{"Accept": "application/json"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		var response OCITagsResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse tags: %w", err)
+		}
+		tags = append(tags, response.Tags...)
+
+		nextURL = ""
+		if matches := linkNextRegex.FindStringSubmatch(resp.Header.Get("Link")); matches != nil {
+			nextURL = matches[1]
+		}
+	}
+
+	return tags, nil
+}
+
+// manifestDigest resolves the digest of a tag, using a HEAD request where the registry
+// reports Docker-Content-Digest and hashing the manifest otherwise
+func (c *ociRegistryClient) manifestDigest(reference string) (string, error) {
+	manifestURL := fmt.Sprintf("/v2/%s/manifests/%s", c.repository, reference)
+	headers := map[string]string{"Accept": ociManifestAccept}
+
+	resp, _, err := c.do("HEAD", manifestURL, headers)
+	if err == nil {
+		if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+			return digest, nil
+		}
+	}
+
+	_, body, err := c.do("GET", manifestURL, headers)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(body)), nil
+}
+
+// lookupDockerConfigCredentials reads credentials for a registry host from the Docker CLI
+// config file ($DOCKER_CONFIG/config.json or ~/.docker/config.json)
+func lookupDockerConfigCredentials(host string) *RegistryCredentials {
+	configDir := os.Getenv("DOCKER_CONFIG")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		configDir = filepath.Join(homeDir, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		return nil
+	}
+
+	var config struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil
+	}
+
+	for key, auth := range config.Auths {
+		if registryHost(key) != host {
+			continue
+		}
+		if auth.Username != "" {
+			return &RegistryCredentials{Username: auth.Username, Password: auth.Password}
+		}
+		if decoded, err := base64.StdEncoding.DecodeString(auth.Auth); err == nil {
+			if username, password, ok := strings.Cut(string(decoded), ":"); ok {
+				return &RegistryCredentials{Username: username, Password: password}
+			}
+		}
+	}
+
+	return nil
+}
+
+// getManifest fetches a manifest by tag or digest, returning it with its digest
+func (c *ociRegistryClient) getManifest(reference string) (*OCIManifest, string, error) {
+	manifestURL := fmt.Sprintf("/v2/%s/manifests/%s", c.repository, reference)
+	resp, body, err := c.do("GET", manifestURL, map[string]string{"Accept": ociManifestAccept})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	var manifest OCIManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+	}
+
+	return &manifest, digest, nil
+}
+
+// imageSize returns the compressed size of an image manifest's config and layers
+func (m *OCIManifest) imageSize() int64 {
+	var size int64
+	if m.Config != nil {
+		size += m.Config.Size
+	}
+	for _, layer := range m.Layers {
+		size += layer.Size
+	}
+	return size
+}
+
+// manifestPlatforms resolves the digest of a tag and the platforms it supports. Image
+// indexes list their platforms directly, while single manifests are resolved through
+// their config blob. Attestation manifests (platform unknown/unknown) are skipped.
+func (c *ociRegistryClient) manifestPlatforms(reference string) (string, []DockerImagePlatform, error) {
+	manifest, digest, err := c.getManifest(reference)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var platforms []DockerImagePlatform
+	if len(manifest.Manifests) == 0 {
+		if manifest.Config == nil {
+			return digest, nil, nil
+		}
+		_, body, err := c.do("GET", fmt.Sprintf("/v2/%s/blobs/%s", c.repository, manifest.Config.Digest), nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to fetch image config: %w", err)
+		}
+		var config OCIImageConfig
+		if err := json.Unmarshal(body, &config); err != nil {
+			return "", nil, fmt.Errorf("failed to parse image config: %w", err)
+		}
+		platforms = append(platforms, DockerImagePlatform{
+			OS:           config.OS,
+			Architecture: config.Architecture,
+			Variant:      config.Variant,
+			Digest:       digest,
+			Size:         manifest.imageSize(),
+		})
+		return digest, platforms, nil
+	}
+
+	for _, descriptor := range manifest.Manifests {
+		if descriptor.Platform == nil || descriptor.Platform.OS == "unknown" {
+			continue
+		}
+		platform := DockerImagePlatform{
+			OS:           descriptor.Platform.OS,
+			Architecture: descriptor.Platform.Architecture,
+			Variant:      descriptor.Platform.Variant,
+			Digest:       descriptor.Digest,
+		}
+		if imageManifest, _, err := c.getManifest(descriptor.Digest); err == nil {
+			platform.Size = imageManifest.imageSize()
+		} else {
+			c.logger.WithFields(logrus.Fields{
+				"digest": descriptor.Digest,
+				"error":  err.Error(),
+			}).Warn("Failed to fetch platform manifest")
+		}
+		platforms = append(platforms, platform)
+	}
+
+	return digest, platforms, nil
+}
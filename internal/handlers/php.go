@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// PackagistURL is the base URL for the Packagist metadata API
+	PackagistURL = "https://repo.packagist.org"
+)
+
+// composerVersionRegex matches a tagged Composer version and captures its stability modifier
+// and dev suffix, following Composer's own version parser
+var composerVersionRegex = regexp.MustCompile(`(?i)^v?\d+(?:\.\d+)*[._-]?(?:(stable|beta|b|rc|alpha|a|patch|pl|p)(?:[.-]?\d+)*)?([.-]?dev)?(?:\+.*)?$`)
+
+// composerDependencySections maps composer.json dependency objects to the suffix used in results
+var composerDependencySections = map[string]string{
+	"require":     "",
+	"require-dev": "dev",
+}
+
+// composerStabilities ranks Composer stability flags from least to most stable
+var composerStabilities = map[string]int{
+	"dev":    0,
+	"alpha":  1,
+	"beta":   2,
+	"rc":     3,
+	"stable": 4,
+}
+
+// PHPHandler handles PHP Composer package version checking
+type PHPHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+}
+
+// NewPHPHandler creates a new PHP handler
+func NewPHPHandler(logger *logrus.Logger, cache *sync.Map) *PHPHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &PHPHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// PackagistPackageResponse represents a response from the Packagist p2 metadata API
+type PackagistPackageResponse struct {
+	Packages map[string][]struct {
+		Version           string `json:"version"`
+		VersionNormalized string `json:"version_normalized"`
+	} `json:"packages"`
+}
+
+// getPackageVersions gets all tagged versions of a Composer package. Branches such as
+// dev-main are listed separately by Packagist (~dev.json) and are never reported, so @dev
+// only admits tagged pre-releases.
+func (h *PHPHandler) getPackageVersions(packageName string) ([]string, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("packagist:%s", packageName)
+	if cachedVersions, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("package", packageName).Debug("Using cached Packagist package versions")
+		return cachedVersions.([]string), nil
+	}
+
+	// Construct URL
+	packageURL := fmt.Sprintf("%s/p2/%s.json", PackagistURL, packageName)
+	h.logger.WithFields(logrus.Fields{
+		"package": packageName,
+		"url":     packageURL,
+	}).Debug("Fetching Packagist package metadata")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", packageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Packagist package metadata: %w", err)
+	}
+
+	// Parse response
+	var response PackagistPackageResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Packagist package metadata: %w", err)
+	}
+
+	versions := make([]string, 0, len(response.Packages[packageName]))
+	for _, version := range response.Packages[packageName] {
+		if version.Version != "" {
+			versions = append(versions, version.Version)
+		}
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, versions)
+
+	return versions, nil
+}
+
+// isComposerPlatformPackage reports whether a requirement refers to the PHP platform
+// rather than a package that can be installed from Packagist
+func isComposerPlatformPackage(name string) bool {
+	name = strings.ToLower(name)
+	switch {
+	case name == "php", name == "php-64bit", name == "hhvm", name == "composer",
+		name == "composer-plugin-api", name == "composer-runtime-api":
+		return true
+	case strings.HasPrefix(name, "ext-"), strings.HasPrefix(name, "lib-"):
+		return true
+	}
+	return false
+}
+
+// parseComposerConstraint splits a Composer version constraint into a comparable version
+// and its stability flag (e.g. "^2.0@beta" becomes "2.0" and "beta")
+func parseComposerConstraint(constraint string) (version, stability string) {
+	stability = "stable"
+
+	// Use the highest alternative of an OR constraint
+	if idx := strings.LastIndex(constraint, "||"); idx != -1 {
+		constraint = constraint[idx+2:]
+	} else if idx := strings.LastIndex(constraint, "|"); idx != -1 {
+		constraint = constraint[idx+1:]
+	}
+	constraint = strings.TrimSpace(constraint)
+
+	if idx := strings.Index(constraint, "@"); idx != -1 {
+		if _, ok := composerStabilities[strings.ToLower(constraint[idx+1:])]; ok {
+			stability = strings.ToLower(constraint[idx+1:])
+		}
+		constraint = constraint[:idx]
+	}
+
+	// Use the lower bound of an AND constraint
+	if fields := strings.Fields(cleanVersionRequirement(constraint)); len(fields) > 0 {
+		version = CleanVersion(fields[0])
+	}
+
+	return version, stability
+}
+
+// composerVersionStability returns the stability of a tagged Composer version from the
+// modifier after its version core, such as the beta of "2.0.0-beta2" or the RC of "1.0.0RC1"
+func composerVersionStability(version string) string {
+	if strings.HasPrefix(strings.ToLower(version), "dev-") {
+		return "dev"
+	}
+
+	match := composerVersionRegex.FindStringSubmatch(version)
+	if match == nil {
+		return "dev"
+	}
+	if match[2] != "" {
+		return "dev"
+	}
+	switch strings.ToLower(match[1]) {
+	case "alpha", "a":
+		return "alpha"
+	case "beta", "b":
+		return "beta"
+	case "rc":
+		return "rc"
+	}
+	return "stable"
+}
+
+// composerVersionOrder ranks a version among versions with the same version core: dev,
+// alpha, beta and RC releases come before the release, and patch releases after it
+func composerVersionOrder(version string) int {
+	if match := composerVersionRegex.FindStringSubmatch(version); match != nil && match[2] == "" {
+		switch strings.ToLower(match[1]) {
+		case "patch", "pl", "p":
+			return composerStabilities["stable"] + 1
+		}
+	}
+	return composerStabilities[composerVersionStability(version)]
+}
+
+// findLatestComposerVersion returns the newest of the versions, which are already filtered
+// by stability. Unlike FindLatestVersion it follows Composer's ordering, so patch releases
+// such as "1.0.0-patch1" are stable and newer than the release they patch.
+func findLatestComposerVersion(versions []string, majorVersion *int) string {
+	var latest string
+	for _, version := range versions {
+		major, _, _, err := ParseVersion(version)
+		if err != nil {
+			continue
+		}
+		if majorVersion != nil && major != *majorVersion {
+			continue
+		}
+
+		if latest == "" {
+			latest = version
+			continue
+		}
+
+		result, err := CompareVersions(version, latest)
+		if err != nil {
+			continue
+		}
+		if result == 0 {
+			result = composerVersionOrder(version) - composerVersionOrder(latest)
+		}
+		if result == 0 {
+			result = comparePrereleaseIdentifiers(strings.ToLower(version), strings.ToLower(latest))
+		}
+		if result > 0 {
+			latest = version
+		}
+	}
+
+	return latest
+}
+
+// GetLatestVersion gets the latest version of PHP packages from composer.json
+func (h *PHPHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest Composer package versions")
+
+	// Parse dependencies
+	depsRaw, ok := args["dependencies"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: dependencies")
+	}
+
+	depsMap, ok := depsRaw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid dependencies format: expected object")
+	}
+
+	// Accept either an object with require/require-dev or a single flat require object
+	sections := make(map[string]map[string]interface{})
+	for section := range composerDependencySections {
+		if sectionDeps, ok := depsMap[section].(map[string]interface{}); ok {
+			sections[section] = sectionDeps
+		}
+	}
+	if len(sections) == 0 {
+		sections["require"] = depsMap
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	// Process each dependency
+	results := make([]PackageVersion, 0)
+	for section, sectionDeps := range sections {
+		for name, versionRaw := range sectionDeps {
+			version, ok := versionRaw.(string)
+			if !ok {
+				version = fmt.Sprintf("%v", versionRaw)
+			}
+
+			result := h.processPackage(name, version, constraints)
+			if suffix := composerDependencySections[section]; suffix != "" {
+				result.Name = fmt.Sprintf("%s (%s)", result.Name, suffix)
+			}
+			results = append(results, result)
+		}
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// processPackage processes a single Composer package
+func (h *PHPHandler) processPackage(name, constraint string, constraints VersionConstraints) PackageVersion {
+	h.logger.WithFields(logrus.Fields{
+		"package":    name,
+		"constraint": constraint,
+	}).Debug("Processing Composer package")
+
+	// Check if package should be excluded
+	if c, ok := constraints[name]; ok && c.ExcludePackage {
+		return PackageVersion{
+			Name:       name,
+			Skipped:    true,
+			SkipReason: "Package excluded by constraints",
+		}
+	}
+
+	// Platform requirements are provided by the PHP installation, not Packagist
+	if isComposerPlatformPackage(name) {
+		return PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(constraint),
+			Registry:       "packagist",
+			Skipped:        true,
+			SkipReason:     "Platform package (PHP runtime or extension) is not available on Packagist",
+		}
+	}
+
+	// Packagist packages are always named vendor/package
+	if !strings.Contains(name, "/") {
+		return PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(constraint),
+			Registry:       "packagist",
+			Skipped:        true,
+			SkipReason:     "Invalid package name, expected vendor/package",
+		}
+	}
+
+	// Branch requirements don't resolve to a tagged version
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(constraint)), "dev-") {
+		return PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(constraint),
+			Registry:       "packagist",
+			Skipped:        true,
+			SkipReason:     "Branch requirement does not track a tagged version",
+		}
+	}
+
+	currentVersion, stability := parseComposerConstraint(constraint)
+
+	// Get package versions
+	packageVersions, err := h.getPackageVersions(strings.ToLower(name))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"package": name,
+			"error":   err.Error(),
+		}).Error("Failed to get Packagist package versions")
+		return PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "packagist",
+			Skipped:        true,
+			SkipReason:     fmt.Sprintf("Failed to fetch package info: %v", err),
+		}
+	}
+
+	// Only consider versions at or above the requested stability
+	minStability := composerStabilities[stability]
+	versions := make([]string, 0, len(packageVersions))
+	for _, version := range packageVersions {
+		if composerStabilities[composerVersionStability(version)] >= minStability {
+			versions = append(versions, version)
+		}
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if c, ok := constraints[name]; ok {
+		majorVersion = c.MajorVersion
+	}
+
+	latestVersion := findLatestComposerVersion(versions, majorVersion)
+
+	if latestVersion == "" {
+		return PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "packagist",
+			Skipped:        true,
+			SkipReason:     fmt.Sprintf("No versions found with %s stability or better", stability),
+		}
+	}
+
+	return PackageVersion{
+		Name:           name,
+		CurrentVersion: StringPtr(currentVersion),
+		LatestVersion:  latestVersion,
+		Registry:       "packagist",
+	}
+}
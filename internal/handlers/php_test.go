@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposerVersionStability(t *testing.T) {
+	tests := map[string]string{
+		"3.5.0":          "stable",
+		"v3.5.0":         "stable",
+		"1.0.0-patch1":   "stable",
+		"2.0.0-alpha1":   "alpha",
+		"2.0.0-a2":       "alpha",
+		"2.0.0-beta.3":   "beta",
+		"2.0.0b1":        "beta",
+		"2.0.0-RC10":     "rc",
+		"2.0.0-dev":      "dev",
+		"dev-main":       "dev",
+		"1.0.0-beta-dev": "dev",
+		// Words that merely contain a or b are not stability modifiers
+		"1.0.0-abc": "dev",
+	}
+
+	for version, want := range tests {
+		assert.Equal(t, want, composerVersionStability(version), version)
+	}
+}
+
+func TestPHPHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Mock Packagist p2 responses, which list each version with only its changed fields
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("repo.packagist.org/p2/monolog/monolog.json", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"minified": "composer/2.0", "packages": {"monolog/monolog": [
+			{"name": "monolog/monolog", "version": "3.7.0", "version_normalized": "3.7.0.0"},
+			{"version": "3.6.0", "version_normalized": "3.6.0.0"},
+			{"version": "3.7.0-patch1", "version_normalized": "3.7.0.0-patch1"},
+			{"version": "4.0.0-beta1", "version_normalized": "4.0.0.0-beta1"}
+		]}}`,
+	})
+	mockClient.AddMockResponse("repo.packagist.org/p2/symfony/console.json", tests.MockResponse{
+		StatusCode: 200,
+		Body: `{"packages": {"symfony/console": [
+			{"version": "v7.2.0-RC1"},
+			{"version": "v7.2.0-BETA2"},
+			{"version": "v7.1.8"}
+		]}}`,
+	})
+	mockClient.AddMockResponse("repo.packagist.org/p2/phpunit/phpunit.json", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `{"packages": {"phpunit/phpunit": [{"version": "11.4.3"}, {"version": "10.5.38"}]}}`,
+	})
+
+	handler := NewPHPHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": map[string]interface{}{
+			"require": map[string]interface{}{
+				"php":             ">=8.1",
+				"ext-json":        "*",
+				"lib-curl":        "*",
+				"monolog":         "^3.6",
+				"monolog/monolog": "^3.6",
+				"symfony/console": "^7.1@beta",
+			},
+			"require-dev": map[string]interface{}{
+				"phpunit/phpunit": "^10.5",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var versions []PackageVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 7)
+
+	// Platform packages are skipped
+	assert.Equal(t, "ext-json", versions[0].Name)
+	assert.True(t, versions[0].Skipped)
+	assert.Contains(t, versions[0].SkipReason, "Platform package")
+	assert.Equal(t, "lib-curl", versions[1].Name)
+	assert.Contains(t, versions[1].SkipReason, "Platform package")
+
+	// Other names without a vendor are invalid rather than platform packages
+	assert.Equal(t, "monolog", versions[2].Name)
+	assert.True(t, versions[2].Skipped)
+	assert.Equal(t, "Invalid package name, expected vendor/package", versions[2].SkipReason)
+
+	// Patch releases are stable and newer than the release they patch
+	assert.Equal(t, "monolog/monolog", versions[3].Name)
+	assert.Equal(t, "3.6", *versions[3].CurrentVersion)
+	assert.Equal(t, "3.7.0-patch1", versions[3].LatestVersion)
+	assert.Equal(t, "packagist", versions[3].Registry)
+
+	assert.Equal(t, "php", versions[4].Name)
+	assert.True(t, versions[4].Skipped)
+	assert.Contains(t, versions[4].SkipReason, "Platform package")
+
+	// require-dev packages are labelled
+	assert.Equal(t, "phpunit/phpunit (dev)", versions[5].Name)
+	assert.Equal(t, "11.4.3", versions[5].LatestVersion)
+
+	// @beta admits beta and RC releases
+	assert.Equal(t, "symfony/console", versions[6].Name)
+	assert.Equal(t, "v7.2.0-RC1", versions[6].LatestVersion)
+}
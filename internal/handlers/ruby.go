@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RubyGemsURL is the base URL for the RubyGems API
+	RubyGemsURL = "https://rubygems.org/api/v1"
+)
+
+// RubyHandler handles Ruby gem version checking
+type RubyHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+}
+
+// NewRubyHandler creates a new Ruby handler
+func NewRubyHandler(logger *logrus.Logger, cache *sync.Map) *RubyHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &RubyHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// RubyGemVersionInfo represents a single version of a gem from the RubyGems API
+type RubyGemVersionInfo struct {
+	Number     string `json:"number"`
+	Platform   string `json:"platform"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// getGemVersions gets all published versions of a gem
+func (h *RubyHandler) getGemVersions(gemName string) ([]RubyGemVersionInfo, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("rubygems:%s", gemName)
+	if cachedVersions, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("gem", gemName).Debug("Using cached gem versions")
+		return cachedVersions.([]RubyGemVersionInfo), nil
+	}
+
+	// Construct URL
+	versionsURL := fmt.Sprintf("%s/versions/%s.json", RubyGemsURL, url.PathEscape(gemName))
+	h.logger.WithFields(logrus.Fields{
+		"gem": gemName,
+		"url": versionsURL,
+	}).Debug("Fetching gem versions")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", versionsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gem versions: %w", err)
+	}
+
+	// Parse response
+	var versions []RubyGemVersionInfo
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, fmt.Errorf("failed to parse gem versions: %w", err)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, versions)
+
+	return versions, nil
+}
+
+// GetLatestVersion gets the latest version of Ruby gems from a Gemfile
+func (h *RubyHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest Ruby gem versions")
+
+	// Parse gems
+	gemsRaw, ok := args["gems"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: gems")
+	}
+
+	// Convert to []RubyGem
+	var gems []RubyGem
+	if gemsArr, ok := gemsRaw.([]interface{}); ok {
+		for _, gemRaw := range gemsArr {
+			if gemMap, ok := gemRaw.(map[string]interface{}); ok {
+				var gem RubyGem
+				if name, ok := gemMap["name"].(string); ok && name != "" {
+					gem.Name = name
+				} else {
+					continue
+				}
+				if requirement, ok := gemMap["requirement"].(string); ok {
+					gem.Requirement = requirement
+				}
+				if group, ok := gemMap["group"].(string); ok {
+					gem.Group = group
+				}
+				gems = append(gems, gem)
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("invalid gems format: expected array")
+	}
+
+	// Parse include prerelease
+	includePrerelease := false
+	if includePrereleaseRaw, ok := args["includePrerelease"].(bool); ok {
+		includePrerelease = includePrereleaseRaw
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	// Process each gem
+	results := make([]PackageVersion, 0, len(gems))
+	for _, gem := range gems {
+		result := h.processGem(gem, constraints, includePrerelease)
+		if gem.Group != "" && gem.Group != "default" {
+			result.Name = fmt.Sprintf("%s (%s)", result.Name, gem.Group)
+		}
+		results = append(results, result)
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// processGem processes a single Ruby gem
+func (h *RubyHandler) processGem(gem RubyGem, constraints VersionConstraints, includePrerelease bool) PackageVersion {
+	h.logger.WithFields(logrus.Fields{
+		"gem":         gem.Name,
+		"requirement": gem.Requirement,
+		"group":       gem.Group,
+	}).Debug("Processing Ruby gem")
+
+	// Check if gem should be excluded
+	if constraint, ok := constraints[gem.Name]; ok && constraint.ExcludePackage {
+		return PackageVersion{
+			Name:       gem.Name,
+			Skipped:    true,
+			SkipReason: "Package excluded by constraints",
+		}
+	}
+
+	// Clean version string
+	currentVersion := cleanVersionRequirement(gem.Requirement)
+
+	// Get gem versions
+	gemVersions, err := h.getGemVersions(gem.Name)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"gem":   gem.Name,
+			"error": err.Error(),
+		}).Error("Failed to get gem versions")
+		return PackageVersion{
+			Name:           gem.Name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "rubygems",
+			Skipped:        true,
+			SkipReason:     fmt.Sprintf("Failed to fetch gem versions: %v", err),
+		}
+	}
+
+	// Platform gems (e.g. java or x86_64-linux builds) repeat the version number once per platform
+	seen := make(map[string]bool)
+	versions := make([]string, 0, len(gemVersions))
+	for _, version := range gemVersions {
+		if (version.Prerelease && !includePrerelease) || seen[version.Number] {
+			continue
+		}
+		seen[version.Number] = true
+		versions = append(versions, version.Number)
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[gem.Name]; ok {
+		majorVersion = constraint.MajorVersion
+	}
+
+	var latestVersion string
+	if includePrerelease {
+		latestVersion = FindLatestVersionWithPrereleases(versions, majorVersion)
+	} else {
+		latestVersion = FindLatestVersion(versions, majorVersion)
+	}
+
+	if latestVersion == "" {
+		return PackageVersion{
+			Name:           gem.Name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  "unknown",
+			Registry:       "rubygems",
+			Skipped:        true,
+			SkipReason:     "No matching versions found",
+		}
+	}
+
+	return PackageVersion{
+		Name:           gem.Name,
+		CurrentVersion: StringPtr(currentVersion),
+		LatestVersion:  latestVersion,
+		Registry:       "rubygems",
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRubyHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Mock RubyGems responses with platform gems and pre-releases
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("rubygems.org/api/v1/versions/nokogiri.json", tests.MockResponse{
+		StatusCode: 200,
+		Body: `[
+			{"number": "1.17.0.rc1", "platform": "ruby", "prerelease": true},
+			{"number": "1.16.5", "platform": "x86_64-linux", "prerelease": false},
+			{"number": "1.16.5", "platform": "java", "prerelease": false},
+			{"number": "1.16.5", "platform": "ruby", "prerelease": false},
+			{"number": "1.15.6", "platform": "ruby", "prerelease": false}
+		]`,
+	})
+	mockClient.AddMockResponse("rubygems.org/api/v1/versions/rails.json", tests.MockResponse{
+		StatusCode: 200,
+		Body: `[
+			{"number": "8.0.0.rc10", "platform": "ruby", "prerelease": true},
+			{"number": "8.0.0.rc2", "platform": "ruby", "prerelease": true},
+			{"number": "7.2.1", "platform": "ruby", "prerelease": false}
+		]`,
+	})
+
+	handler := NewRubyHandler(logger, &sync.Map{})
+	handler.client = mockClient
+
+	gems := []interface{}{
+		map[string]interface{}{"name": "nokogiri", "requirement": "~> 1.15"},
+		map[string]interface{}{"name": "rails", "requirement": "7.2.0", "group": "development"},
+	}
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{"gems": gems})
+	require.NoError(t, err)
+
+	var versions []PackageVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 2)
+
+	// Platform gems share the stable version and pre-releases are ignored
+	assert.Equal(t, "nokogiri", versions[0].Name)
+	assert.Equal(t, "1.16.5", versions[0].LatestVersion)
+	assert.Equal(t, "rubygems", versions[0].Registry)
+	assert.Equal(t, "rails (development)", versions[1].Name)
+	assert.Equal(t, "7.2.1", versions[1].LatestVersion)
+
+	// Pre-releases are compared numerically when included
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"gems":              gems,
+		"includePrerelease": true,
+	})
+	require.NoError(t, err)
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.17.0.rc1", versions[0].LatestVersion)
+	assert.Equal(t, "8.0.0.rc10", versions[1].LatestVersion)
+}
@@ -3,7 +3,10 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -12,11 +15,45 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// SwiftRegistryAcceptHeader is the media type of version 1 of the Swift Package Registry API
+	SwiftRegistryAcceptHeader = "application/vnd.swift.registry.v1+json"
+)
+
+var (
+	// swiftRegistryIdentityRegex matches Swift Package Registry identities such as mona.LinkedList
+	swiftRegistryIdentityRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]{0,38}\.[A-Za-z0-9][A-Za-z0-9_-]{0,99}$`)
+	// swiftRangeRegex matches "1.2.3"..<"2.0.0" and "1.2.3"..."1.5.0" version ranges
+	swiftRangeRegex = regexp.MustCompile(`"?([0-9]+(?:\.[0-9A-Za-z+-]+)*)"?\s*(\.\.<|\.\.\.)\s*"?([0-9]+(?:\.[0-9A-Za-z+-]+)*)"?`)
+	// swiftQuotedValueRegex matches the quoted version, branch or revision of a requirement
+	swiftQuotedValueRegex = regexp.MustCompile(`"([^"]*)"`)
+	// swiftRequirementKindRegex matches the label or case name a requirement starts with, such as
+	// exact: "1.2.3", .exact("1.2.3"), branch: "main" or .upToNextMinor(from: "1.2.0")
+	swiftRequirementKindRegex = regexp.MustCompile(`(?i)^\.?(uptonextminor|uptonextmajor|exact|branch|revision|from)\s*(?:[:(]|$)`)
+)
+
 // SwiftHandler handles Swift package version checking
 type SwiftHandler struct {
 	client HTTPClient
 	cache  *sync.Map
 	logger *logrus.Logger
+	forges map[string]ForgeConfig
+	// registryURL and registryToken configure the Swift Package Registry used for
+	// scope.name package identities
+	registryURL   string
+	registryToken string
+}
+
+// SwiftRegistryReleases represents a Swift Package Registry release listing
+type SwiftRegistryReleases struct {
+	Releases map[string]struct {
+		URL string `json:"url"`
+		// Problem is set for releases that were removed or are otherwise unavailable
+		Problem *struct {
+			Status int    `json:"status"`
+			Detail string `json:"detail"`
+		} `json:"problem"`
+	} `json:"releases"`
 }
 
 // NewSwiftHandler creates a new Swift handler
@@ -28,16 +65,112 @@ func NewSwiftHandler(logger *logrus.Logger, cache *sync.Map) *SwiftHandler {
 		client: DefaultHTTPClient,
 		cache:  cache,
 		logger: logger,
+		forges: forgeConfigsFromEnv(logger),
+
+		registryURL:   os.Getenv("SWIFT_REGISTRY_URL"),
+		registryToken: os.Getenv("SWIFT_REGISTRY_TOKEN"),
+	}
+}
+
+// isSwiftRegistryIdentity reports whether an id is a valid Swift Package Registry identity
+func isSwiftRegistryIdentity(reference string) bool {
+	return swiftRegistryIdentityRegex.MatchString(reference)
+}
+
+// swiftRequirement is a parsed Package.swift dependency requirement
+type swiftRequirement struct {
+	kind           string
+	lower          string
+	upper          string
+	upperInclusive bool
+	ref            string
+}
+
+// parseSwiftRequirement parses a Package.swift requirement such as from: "1.2.3",
+// .upToNextMinor(from: "1.2.0"), exact: "1.2.3", "1.0.0"..<"2.0.0" or branch: "main".
+// The version is used when the requirement doesn't include one.
+func parseSwiftRequirement(requirement, version string) swiftRequirement {
+	requirement = strings.TrimSpace(requirement)
+	if match := swiftRangeRegex.FindStringSubmatch(requirement); match != nil {
+		return swiftRequirement{
+			kind:           "range",
+			lower:          match[1],
+			upper:          match[3],
+			upperInclusive: match[2] == "...",
+		}
+	}
+
+	// Use the quoted value, or whatever follows the label or opening parenthesis
+	value := version
+	if match := swiftQuotedValueRegex.FindStringSubmatch(requirement); match != nil {
+		value = match[1]
+	} else if index := strings.LastIndexAny(requirement, ":("); index != -1 {
+		if unquoted := strings.Trim(requirement[index+1:], " )"); unquoted != "" {
+			value = unquoted
+		}
 	}
+
+	// Only the label or case name decides the kind, so a branch such as "exact-fix" stays a branch
+	if match := swiftRequirementKindRegex.FindStringSubmatch(requirement); match != nil {
+		switch strings.ToLower(match[1]) {
+		case "uptonextminor":
+			return swiftRequirement{kind: "upToNextMinor", lower: value}
+		case "uptonextmajor":
+			return swiftRequirement{kind: "upToNextMajor", lower: value}
+		case "exact":
+			return swiftRequirement{kind: "exact", lower: value}
+		case "branch":
+			return swiftRequirement{kind: "branch", ref: value}
+		case "revision":
+			return swiftRequirement{kind: "revision", ref: value}
+		}
+	}
+
+	// from: "1.2.3" and a bare version both mean up to the next major version
+	if _, _, _, err := ParseVersion(requirement); err == nil {
+		value = requirement
+	}
+	return swiftRequirement{kind: "from", lower: value}
 }
 
-// GitHubReleaseResponse represents a response from the GitHub API for releases
-type GitHubReleaseResponse []struct {
-	TagName string `json:"tag_name"`
-	Name    string `json:"name"`
-	Draft   bool   `json:"draft"`
-	Prerelease bool `json:"prerelease"`
-	PublishedAt string `json:"published_at"`
+// allows reports whether a version satisfies the requirement. Branch and revision
+// requirements never match a version.
+func (r swiftRequirement) allows(version string) bool {
+	if r.lower == "" {
+		return false
+	}
+	lowerResult, err := CompareVersions(version, r.lower)
+	if err != nil {
+		return false
+	}
+
+	major, minor, _, err := ParseVersion(version)
+	if err != nil {
+		return false
+	}
+	lowerMajor, lowerMinor, _, err := ParseVersion(r.lower)
+	if err != nil {
+		return false
+	}
+
+	switch r.kind {
+	case "from", "upToNextMajor":
+		return lowerResult >= 0 && major == lowerMajor
+	case "upToNextMinor":
+		return lowerResult >= 0 && major == lowerMajor && minor == lowerMinor
+	case "exact":
+		return lowerResult == 0
+	case "range":
+		upperResult, err := CompareVersions(version, r.upper)
+		if err != nil {
+			return false
+		}
+		if r.upperInclusive {
+			return lowerResult >= 0 && upperResult <= 0
+		}
+		return lowerResult >= 0 && upperResult < 0
+	}
+	return false
 }
 
 // GetLatestVersion gets the latest version of Swift packages
@@ -58,7 +191,11 @@ func (h *SwiftHandler) GetLatestVersion(ctx context.Context, args map[string]int
 				var dep SwiftDependency
 				if url, ok := depMap["url"].(string); ok {
 					dep.URL = url
-				} else {
+				}
+				if id, ok := depMap["id"].(string); ok {
+					dep.ID = id
+				}
+				if dep.URL == "" && dep.ID == "" {
 					continue
 				}
 				if version, ok := depMap["version"].(string); ok {
@@ -75,88 +212,50 @@ func (h *SwiftHandler) GetLatestVersion(ctx context.Context, args map[string]int
 	}
 
 	// Parse constraints
-	var constraints VersionConstraints
-	if constraintsRaw, ok := args["constraints"]; ok {
-		if constraintsMap, ok := constraintsRaw.(map[string]interface{}); ok {
-			constraints = make(VersionConstraints)
-			for name, constraintRaw := range constraintsMap {
-				if constraintMap, ok := constraintRaw.(map[string]interface{}); ok {
-					var constraint VersionConstraint
-					if majorVersion, ok := constraintMap["majorVersion"].(float64); ok {
-						majorInt := int(majorVersion)
-						constraint.MajorVersion = &majorInt
-					}
-					if excludePackage, ok := constraintMap["excludePackage"].(bool); ok {
-						constraint.ExcludePackage = excludePackage
-					}
-					constraints[name] = constraint
-				}
-			}
-		}
+	constraints := parseVersionConstraints(args)
+
+	// Parse registry URL, falling back to SWIFT_REGISTRY_URL
+	registryURL := h.registryURL
+	if registryRaw, ok := args["registry"].(string); ok && registryRaw != "" {
+		registryURL = registryRaw
 	}
 
-	// Process each dependency
-	results := make([]PackageVersion, 0, len(deps))
+	// Load every repository at once, grouped by the forge hosting it, when the forge
+	// supports batched queries
+	forges := make(map[string]Forge)
+	projects := make(map[string][]string)
 	for _, dep := range deps {
-		h.logger.WithFields(logrus.Fields{
-			"url":     dep.URL,
-			"version": dep.Version,
-		}).Debug("Processing Swift package")
-
-		// Check if package should be excluded
-		if constraint, ok := constraints[dep.URL]; ok && constraint.ExcludePackage {
-			results = append(results, PackageVersion{
-				Name:       dep.URL,
-				Skipped:    true,
-				SkipReason: "Package excluded by constraints",
-			})
-			continue
-		}
-
-		// Get latest version
-		latestVersion, err := h.getLatestVersion(dep.URL)
+		host, project, err := parseRepositoryURL(dep.URL)
 		if err != nil {
-			h.logger.WithFields(logrus.Fields{
-				"url":   dep.URL,
-				"error": err.Error(),
-			}).Error("Failed to get Swift package info")
-			results = append(results, PackageVersion{
-				Name:           dep.URL,
-				CurrentVersion: StringPtr(dep.Version),
-				LatestVersion:  "unknown",
-				Registry:       "swift",
-				Skipped:        true,
-				SkipReason:     fmt.Sprintf("Failed to fetch package info: %v", err),
-			})
 			continue
 		}
-
-		// Apply major version constraint if specified
-		if constraint, ok := constraints[dep.URL]; ok && constraint.MajorVersion != nil {
-			targetMajor := *constraint.MajorVersion
-			latestMajor, _, _, err := ParseVersion(latestVersion)
-			if err == nil && latestMajor > targetMajor {
-				// Find the latest version with the target major version
-				h.logger.WithFields(logrus.Fields{
-					"url":          dep.URL,
-					"targetMajor":  targetMajor,
-					"latestMajor":  latestMajor,
-					"latestVersion": latestVersion,
-				}).Debug("Applying major version constraint")
-
-				// In a real implementation, this would fetch all versions and filter by major version
-				// For now, we'll just append the major version
-				latestVersion = fmt.Sprintf("%d.0.0", targetMajor)
+		if _, ok := forges[host]; !ok {
+			forge, err := newForge(h.client, h.logger, h.cache, h.forges, host)
+			if err != nil {
+				continue
 			}
+			forges[host] = forge
 		}
+		projects[host] = append(projects[host], project)
+	}
+	for host, hostProjects := range projects {
+		if err := prefetchRepositories(h.logger, forges[host], hostProjects); err != nil {
+			var rateLimitErr *RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				return NewToolResultRateLimitError(rateLimitErr)
+			}
+		}
+	}
 
-		// Add result
-		results = append(results, PackageVersion{
-			Name:           dep.URL,
-			CurrentVersion: StringPtr(dep.Version),
-			LatestVersion:  latestVersion,
-			Registry:       "swift",
-		})
+	// Process each dependency
+	results := make([]SwiftPackageVersion, 0, len(deps))
+	for _, dep := range deps {
+		result, err := h.processDependency(dep, constraints, forges, registryURL)
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			return NewToolResultRateLimitError(rateLimitErr)
+		}
+		results = append(results, result)
 	}
 
 	// Sort results by name
@@ -167,133 +266,278 @@ func (h *SwiftHandler) GetLatestVersion(ctx context.Context, args map[string]int
 	return NewToolResultJSON(results)
 }
 
-// getLatestVersion gets the latest version of a Swift package
-func (h *SwiftHandler) getLatestVersion(packageURL string) (string, error) {
-	// Check cache first
-	cacheKey := fmt.Sprintf("swift:%s", packageURL)
-	if cachedVersion, ok := h.cache.Load(cacheKey); ok {
-		h.logger.WithField("url", packageURL).Debug("Using cached Swift package version")
-		return cachedVersion.(string), nil
+// processDependency checks a single Swift package dependency. Other failures are reported
+// on the result, so an error is only returned when a forge or registry rate limit is exhausted.
+func (h *SwiftHandler) processDependency(dep SwiftDependency, constraints VersionConstraints, forges map[string]Forge, registryURL string) (SwiftPackageVersion, error) {
+	// Registry packages are named by their identity, others by their URL
+	name := dep.URL
+	if dep.ID != "" {
+		name = dep.ID
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"package":     name,
+		"version":     dep.Version,
+		"requirement": dep.Requirement,
+	}).Debug("Processing Swift package")
+
+	// Check if package should be excluded
+	if constraint, ok := constraints[name]; ok && constraint.ExcludePackage {
+		return SwiftPackageVersion{
+			PackageVersion: PackageVersion{
+				Name:       name,
+				Skipped:    true,
+				SkipReason: "Package excluded by constraints",
+			},
+		}, nil
+	}
+
+	requirement := parseSwiftRequirement(dep.Requirement, dep.Version)
+	currentVersion := dep.Version
+	if currentVersion == "" {
+		currentVersion = requirement.lower
+	}
+	if currentVersion == "" {
+		currentVersion = requirement.ref
 	}
 
-	// Parse GitHub URL
-	if !strings.Contains(packageURL, "github.com") {
-		return "", fmt.Errorf("only GitHub URLs are supported: %s", packageURL)
+	// Get every released version
+	var versions []string
+	var err error
+	if dep.ID != "" {
+		versions, err = h.getRegistryVersions(registryURL, dep.ID)
+	} else {
+		// Only an explicit requirement decides whether tags are needed for its allowed versions
+		var tagsRequirement swiftRequirement
+		if dep.Requirement != "" {
+			tagsRequirement = requirement
+		}
+		versions, err = h.getVersions(dep.URL, tagsRequirement)
+	}
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return SwiftPackageVersion{}, err
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"package": name,
+			"error":   err.Error(),
+		}).Error("Failed to get Swift package info")
+		return SwiftPackageVersion{
+			PackageVersion: PackageVersion{
+				Name:           name,
+				CurrentVersion: StringPtr(currentVersion),
+				LatestVersion:  "unknown",
+				Registry:       "swift",
+				Skipped:        true,
+				SkipReason:     fmt.Sprintf("Failed to fetch package info: %v", err),
+			},
+		}, nil
 	}
 
-	// Extract owner and repo
-	parts := strings.Split(packageURL, "/")
-	if len(parts) < 5 {
-		return "", fmt.Errorf("invalid GitHub URL format: %s", packageURL)
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[name]; ok {
+		majorVersion = constraint.MajorVersion
 	}
 
-	owner := parts[3]
-	repo := parts[4]
+	result := SwiftPackageVersion{
+		PackageVersion: PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(currentVersion),
+			LatestVersion:  FindLatestVersion(versions, majorVersion),
+			Registry:       "swift",
+		},
+		RequirementKind: requirement.kind,
+	}
+	if result.LatestVersion == "" {
+		result.LatestVersion = "unknown"
+		result.Skipped = true
+		result.SkipReason = "No stable versions found"
+		return result, nil
+	}
 
-	// Construct API URL
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", owner, repo)
-	h.logger.WithFields(logrus.Fields{
-		"url":    packageURL,
-		"apiURL": apiURL,
-	}).Debug("Fetching Swift package releases")
+	// Report the newest version the Package.swift requirement still accepts. Branch and
+	// revision requirements don't accept any version without changing the requirement.
+	if dep.Requirement != "" {
+		var allowed []string
+		for _, version := range versions {
+			if requirement.allows(version) {
+				allowed = append(allowed, version)
+			}
+		}
+		if latestAllowed := FindLatestVersion(allowed, majorVersion); latestAllowed != "" {
+			result.LatestAllowed = StringPtr(latestAllowed)
+		}
+		result.UpdateRequiresConstraintChange = result.LatestAllowed == nil || *result.LatestAllowed != result.LatestVersion
+	}
 
-	// Make request
-	headers := map[string]string{
-		"Accept": "application/vnd.github.v3+json",
+	// Add repository metadata when it was loaded by a batched query
+	if host, project, err := parseRepositoryURL(dep.URL); err == nil && forges[host] != nil {
+		if repository := forgeRepository(forges[host], project); repository != nil {
+			result.Archived = repository.Archived
+			if repository.DefaultBranch != "" {
+				result.DefaultBranch = StringPtr(repository.DefaultBranch)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getVersions gets every version of a Swift package from its repository's releases and
+// tags, leaving out tags whose release is a draft or pre-release. Listing tags takes several
+// requests, so they're only listed when a batched query already loaded them or the releases
+// have no stable version the requirement allows.
+func (h *SwiftHandler) getVersions(packageURL string, requirement swiftRequirement) ([]string, error) {
+	// Check cache first, preferring versions that include the tags
+	cacheKey := fmt.Sprintf("swift-versions:%s", packageURL)
+	if cachedVersions, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("url", packageURL).Debug("Using cached Swift package versions")
+		return cachedVersions.([]string), nil
 	}
-	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", apiURL, headers)
+	releasesCacheKey := fmt.Sprintf("swift-release-versions:%s", packageURL)
+	if cachedVersions, ok := h.cache.Load(releasesCacheKey); ok && !swiftNeedsTags(cachedVersions.([]string), requirement) {
+		h.logger.WithField("url", packageURL).Debug("Using cached Swift package release versions")
+		return cachedVersions.([]string), nil
+	}
+
+	// Find the forge hosting the package repository
+	host, project, err := parseRepositoryURL(packageURL)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch Swift package releases: %w", err)
+		return nil, err
+	}
+	forge, err := newForge(h.client, h.logger, h.cache, h.forges, host)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse response
-	var releases GitHubReleaseResponse
-	if err := json.Unmarshal(body, &releases); err != nil {
-		return "", fmt.Errorf("failed to parse Swift package releases: %w", err)
+	h.logger.WithFields(logrus.Fields{
+		"url":     packageURL,
+		"forge":   host,
+		"project": project,
+	}).Debug("Fetching Swift package releases and tags")
+
+	releases, releasesErr := forge.Releases(project)
+	var rateLimitErr *RateLimitError
+	if errors.As(releasesErr, &rateLimitErr) {
+		return nil, releasesErr
 	}
 
-	// Find latest non-draft, non-prerelease version
-	var latestVersion string
+	seen := make(map[string]bool)
+	var versions []string
 	for _, release := range releases {
+		seen[release.TagName] = true
 		if release.Draft || release.Prerelease {
 			continue
 		}
+		versions = append(versions, strings.TrimPrefix(release.TagName, "v"))
+	}
 
-		version := strings.TrimPrefix(release.TagName, "v")
-		if latestVersion == "" {
-			latestVersion = version
-			continue
+	// SwiftPM resolves versions from tags, which may not all have releases
+	if releasesErr == nil && !forgeHasTags(forge, project) && !swiftNeedsTags(versions, requirement) {
+		h.cache.Store(releasesCacheKey, versions)
+		return versions, nil
+	}
+	tags, tagsErr := forge.Tags(project)
+	if errors.As(tagsErr, &rateLimitErr) {
+		return nil, tagsErr
+	}
+	for _, tag := range tags {
+		if !seen[tag.Name] {
+			seen[tag.Name] = true
+			versions = append(versions, strings.TrimPrefix(tag.Name, "v"))
 		}
+	}
 
-		// Compare versions
-		result, err := CompareVersions(version, latestVersion)
-		if err != nil {
-			h.logger.WithFields(logrus.Fields{
-				"version1": version,
-				"version2": latestVersion,
-				"error":    err.Error(),
-			}).Debug("Failed to compare versions")
-			continue
+	if len(versions) == 0 {
+		switch {
+		case releasesErr != nil:
+			return nil, fmt.Errorf("failed to fetch Swift package releases: %w", releasesErr)
+		case tagsErr != nil:
+			return nil, fmt.Errorf("failed to fetch Swift package tags: %w", tagsErr)
 		}
+		return nil, fmt.Errorf("no releases or tags found for: %s", packageURL)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, versions)
+
+	return versions, nil
+}
 
-		if result > 0 {
-			latestVersion = version
+// swiftNeedsTags reports whether the tags of a package have to be listed because its
+// releases have no stable version, or none the requirement allows
+func swiftNeedsTags(versions []string, requirement swiftRequirement) bool {
+	if FindLatestVersion(versions, nil) == "" {
+		return true
+	}
+	if requirement.lower == "" {
+		return false
+	}
+	for _, version := range versions {
+		if requirement.allows(version) {
+			return false
 		}
 	}
+	return true
+}
 
-	if latestVersion == "" {
-		// If no releases found, try tags
-		tagsURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/tags", owner, repo)
-		h.logger.WithFields(logrus.Fields{
-			"url":     packageURL,
-			"tagsURL": tagsURL,
-		}).Debug("Fetching Swift package tags")
+// getRegistryVersions gets every available version of a package from a Swift Package
+// Registry, leaving out releases the registry reports a problem for
+func (h *SwiftHandler) getRegistryVersions(registryURL, identity string) ([]string, error) {
+	if registryURL == "" {
+		return nil, fmt.Errorf("no Swift package registry configured for %s, set SWIFT_REGISTRY_URL or registry", identity)
+	}
+	registryURL = strings.TrimSuffix(registryURL, "/")
 
-		// Make request
-		body, err := MakeRequestWithLogger(h.client, h.logger, "GET", tagsURL, headers)
-		if err != nil {
-			return "", fmt.Errorf("failed to fetch Swift package tags: %w", err)
-		}
+	// Check cache first
+	cacheKey := fmt.Sprintf("swift-registry-versions:%s/%s", registryURL, identity)
+	if cachedVersions, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("package", identity).Debug("Using cached Swift registry versions")
+		return cachedVersions.([]string), nil
+	}
 
-		// Parse response
-		var tags []struct {
-			Name string `json:"name"`
-		}
-		if err := json.Unmarshal(body, &tags); err != nil {
-			return "", fmt.Errorf("failed to parse Swift package tags: %w", err)
-		}
+	if !isSwiftRegistryIdentity(identity) {
+		return nil, fmt.Errorf("invalid Swift package registry identity %s, expected scope.name", identity)
+	}
+	scope, name, _ := strings.Cut(identity, ".")
+	headers := map[string]string{
+		"Accept": SwiftRegistryAcceptHeader,
+	}
+	// The token is only sent to SWIFT_REGISTRY_URL, never to a registry given as an argument
+	if h.registryToken != "" && registryURL == strings.TrimSuffix(h.registryURL, "/") {
+		headers["Authorization"] = "Bearer " + h.registryToken
+	}
 
-		// Find latest version
-		for _, tag := range tags {
-			version := strings.TrimPrefix(tag.Name, "v")
-			if latestVersion == "" {
-				latestVersion = version
-				continue
-			}
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", fmt.Sprintf("%s/%s/%s", registryURL, scope, name), headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Swift registry releases: %w", err)
+	}
 
-			// Compare versions
-			result, err := CompareVersions(version, latestVersion)
-			if err != nil {
-				h.logger.WithFields(logrus.Fields{
-					"version1": version,
-					"version2": latestVersion,
-					"error":    err.Error(),
-				}).Debug("Failed to compare versions")
-				continue
-			}
+	var listing SwiftRegistryReleases
+	if err := json.Unmarshal(body, &listing); err != nil {
+		return nil, fmt.Errorf("failed to parse Swift registry releases: %w", err)
+	}
 
-			if result > 0 {
-				latestVersion = version
-			}
+	versions := make([]string, 0, len(listing.Releases))
+	for version, release := range listing.Releases {
+		if release.Problem != nil {
+			h.logger.WithFields(logrus.Fields{
+				"package": identity,
+				"version": version,
+				"detail":  release.Problem.Detail,
+			}).Debug("Skipping unavailable Swift registry release")
+			continue
 		}
+		versions = append(versions, version)
 	}
-
-	if latestVersion == "" {
-		return "", fmt.Errorf("no releases or tags found for: %s", packageURL)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no releases found for: %s", identity)
 	}
 
 	// Cache result
-	h.cache.Store(cacheKey, latestVersion)
+	h.cache.Store(cacheKey, versions)
 
-	return latestVersion, nil
+	return versions, nil
 }
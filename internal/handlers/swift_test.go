@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sammcj/mcp-package-version/v2/internal/handlers/tests"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwiftHandler_SelfHostedForges(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	mockClient := tests.NewMockClient()
+	mockClient.AddMockResponse("gitlab.example.com/api/v4/projects/ios%2Fshared%2Fnetworking/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "v2.1.0"}, {"tag_name": "v2.0.0"}]`,
+	})
+	// Without releases, the newest tag is used
+	mockClient.AddMockResponse("git.example.com/api/v1/repos/ios/logging/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[]`,
+	})
+	mockClient.AddMockResponse("git.example.com/api/v1/repos/ios/logging/tags", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"name": "1.4.0", "commit": {"sha": "a"}}, {"name": "1.10.0", "commit": {"sha": "b"}}]`,
+	})
+
+	handler := NewSwiftHandler(logger, &sync.Map{})
+	handler.client = mockClient
+	handler.forges["gitlab.example.com"] = ForgeConfig{Type: ForgeGitLab, Host: "gitlab.example.com", APIURL: "https://gitlab.example.com/api/v4"}
+	handler.forges["git.example.com"] = ForgeConfig{Type: ForgeGitea, Host: "git.example.com", APIURL: "https://git.example.com/api/v1"}
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"url": "https://gitlab.example.com/ios/shared/networking.git", "version": "2.0.0"},
+			map[string]interface{}{"url": "git@git.example.com:ios/logging.git", "version": "1.4.0"},
+			map[string]interface{}{"url": "https://unknown.example.com/ios/other.git", "version": "1.0.0"},
+		},
+	})
+	require.NoError(t, err)
+
+	var results []PackageVersion
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "git@git.example.com:ios/logging.git", results[0].Name)
+	assert.Equal(t, "1.10.0", results[0].LatestVersion)
+	assert.Equal(t, "https://gitlab.example.com/ios/shared/networking.git", results[1].Name)
+	assert.Equal(t, "2.1.0", results[1].LatestVersion)
+	assert.True(t, results[2].Skipped)
+	assert.Contains(t, results[2].SkipReason, "unknown forge host unknown.example.com")
+}
+
+func TestSwiftRequirementAllows(t *testing.T) {
+	tests := []struct {
+		requirement string
+		version     string
+		kind        string
+		allowed     []string
+		disallowed  []string
+	}{
+		{`from: "2.60.0"`, "", "from", []string{"2.60.0", "2.65.0"}, []string{"2.59.0", "3.0.0"}},
+		{`.upToNextMajor(from: "2.60.0")`, "", "upToNextMajor", []string{"2.65.0"}, []string{"3.0.0"}},
+		{`.upToNextMinor(from: "2.60.0")`, "", "upToNextMinor", []string{"2.60.1"}, []string{"2.61.0"}},
+		{`exact: "2.60.0"`, "", "exact", []string{"2.60.0"}, []string{"2.60.1"}},
+		{`"2.60.0"..<"2.65.0"`, "", "range", []string{"2.64.9"}, []string{"2.65.0"}},
+		{`"2.60.0"..."2.65.0"`, "", "range", []string{"2.65.0"}, []string{"2.65.1"}},
+		{`.exact("2.60.0")`, "", "exact", []string{"2.60.0"}, []string{"2.60.1"}},
+		{`branch: "main"`, "", "branch", nil, []string{"2.65.0"}},
+		{`branch: "exact-fix"`, "", "branch", nil, []string{"2.65.0"}},
+		{`.branch("upToNextMinor")`, "", "branch", nil, []string{"2.65.0"}},
+		{`.revision("0a1b2c3")`, "", "revision", nil, []string{"2.65.0"}},
+		{`revision: "0a1b2c3"`, "", "revision", nil, []string{"2.65.0"}},
+		{"upToNextMinor", "2.60.0", "upToNextMinor", []string{"2.60.1"}, []string{"2.61.0"}},
+		{"2.60.0", "", "from", []string{"2.65.0"}, []string{"3.0.0"}},
+	}
+
+	for _, tt := range tests {
+		requirement := parseSwiftRequirement(tt.requirement, tt.version)
+		assert.Equal(t, tt.kind, requirement.kind, tt.requirement)
+		for _, version := range tt.allowed {
+			assert.True(t, requirement.allows(version), "%s should allow %s", tt.requirement, version)
+		}
+		for _, version := range tt.disallowed {
+			assert.False(t, requirement.allows(version), "%s should not allow %s", tt.requirement, version)
+		}
+	}
+}
+
+func TestSwiftHandler_Requirements(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	mockClient := tests.NewMockClient()
+	// Versions come from releases and tags, leaving out pre-releases
+	mockClient.AddMockResponse("repos/apple/swift-nio/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "3.0.0-beta.1", "prerelease": true}, {"tag_name": "2.65.0"}, {"tag_name": "2.60.1"}]`,
+	})
+	mockClient.AddMockResponse("repos/apple/swift-nio/tags", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"name": "3.1.0"}, {"name": "3.0.0-beta.1"}, {"name": "2.65.0"}, {"name": "2.60.1"}, {"name": "2.60.0"}]`,
+	})
+	mockClient.AddMockResponse("repos/apple/swift-log/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[]`,
+	})
+	mockClient.AddMockResponse("repos/apple/swift-log/tags", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"name": "1.6.1"}, {"name": "1.5.0"}]`,
+	})
+	mockClient.AddMockResponse("repos/apple/swift-collections/releases", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"tag_name": "1.1.0"}, {"tag_name": "1.0.0"}]`,
+	})
+	mockClient.AddMockResponse("repos/apple/swift-collections/tags", tests.MockResponse{
+		StatusCode: 200,
+		Body:       `[{"name": "1.1.0"}, {"name": "1.0.0"}]`,
+	})
+
+	handler := NewSwiftHandler(logger, &sync.Map{})
+	handler.client = mockClient
+	handler.forges["git.example.com"] = ForgeConfig{Type: ForgeGitea, Host: "git.example.com", APIURL: "https://git.example.com/api/v1"}
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"url": "https://git.example.com/apple/swift-nio", "requirement": `.upToNextMinor(from: "2.60.0")`},
+			map[string]interface{}{"url": "https://git.example.com/apple/swift-log", "requirement": `branch: "main"`},
+			map[string]interface{}{"url": "https://git.example.com/apple/swift-collections", "version": "1.0.0", "requirement": "from"},
+		},
+		"constraints": map[string]interface{}{
+			"https://git.example.com/apple/swift-nio": map[string]interface{}{"majorVersion": float64(2)},
+		},
+	})
+	require.NoError(t, err)
+
+	var results []SwiftPackageVersion
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 3)
+
+	// A from requirement accepts the latest version
+	assert.Equal(t, "1.1.0", results[0].LatestVersion)
+	require.NotNil(t, results[0].LatestAllowed)
+	assert.Equal(t, "1.1.0", *results[0].LatestAllowed)
+	assert.False(t, results[0].UpdateRequiresConstraintChange)
+
+	// A branch requirement has to change to use any version
+	require.NotNil(t, results[1].CurrentVersion)
+	assert.Equal(t, "main", *results[1].CurrentVersion)
+	assert.Equal(t, "branch", results[1].RequirementKind)
+	assert.Equal(t, "1.6.1", results[1].LatestVersion)
+	assert.Nil(t, results[1].LatestAllowed)
+	assert.True(t, results[1].UpdateRequiresConstraintChange)
+
+	// The major version constraint picks the newest 2.x release
+	require.NotNil(t, results[2].CurrentVersion)
+	assert.Equal(t, "2.60.0", *results[2].CurrentVersion)
+	assert.Equal(t, "upToNextMinor", results[2].RequirementKind)
+	assert.Equal(t, "2.65.0", results[2].LatestVersion)
+	require.NotNil(t, results[2].LatestAllowed)
+	assert.Equal(t, "2.60.1", *results[2].LatestAllowed)
+	assert.True(t, results[2].UpdateRequiresConstraintChange)
+}
+
+func TestSwiftHandler_TagsOnlyWhenNeeded(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	tagRequests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/acme/released/releases":
+			_, _ = w.Write([]byte(`[{"tag_name": "v2.1.0"}, {"tag_name": "v2.0.0"}]`))
+		case "/repos/acme/window/releases":
+			_, _ = w.Write([]byte(`[{"tag_name": "v3.0.0"}]`))
+		case "/repos/acme/tagged/releases":
+			_, _ = w.Write([]byte(`[]`))
+		case "/repos/acme/released/tags", "/repos/acme/window/tags", "/repos/acme/tagged/tags":
+			tagRequests[r.URL.Path]++
+			_, _ = w.Write([]byte(`[{"name": "v3.0.0"}, {"name": "v2.4.1"}, {"name": "v2.4.0"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	handler := NewSwiftHandler(logger, &sync.Map{})
+	handler.client = server.Client()
+	handler.forges[DefaultForgeHost] = ForgeConfig{Type: ForgeGitHub, Host: DefaultForgeHost, APIURL: server.URL}
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"url": "https://github.com/acme/released", "requirement": `from: "2.0.0"`},
+			map[string]interface{}{"url": "https://github.com/acme/window", "requirement": `.upToNextMinor(from: "2.4.0")`},
+			map[string]interface{}{"url": "https://github.com/acme/tagged", "version": "2.4.0"},
+		},
+	})
+	require.NoError(t, err)
+
+	var results []SwiftPackageVersion
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 3)
+
+	// Releases that satisfy the requirement don't need the tags
+	assert.Equal(t, "2.1.0", results[0].LatestVersion)
+	require.NotNil(t, results[0].LatestAllowed)
+	assert.Equal(t, "2.1.0", *results[0].LatestAllowed)
+
+	// Tags are listed when there are no releases at all
+	assert.Equal(t, "3.0.0", results[1].LatestVersion)
+
+	// and for the versions the requirement allows when no release has them
+	assert.Equal(t, "3.0.0", results[2].LatestVersion)
+	require.NotNil(t, results[2].LatestAllowed)
+	assert.Equal(t, "2.4.1", *results[2].LatestAllowed)
+
+	assert.Equal(t, map[string]int{"/repos/acme/window/tags": 1, "/repos/acme/tagged/tags": 1}, tagRequests)
+}
+
+func TestSwiftHandler_Registry(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Stand-in Swift Package Registry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != SwiftRegistryAcceptHeader || r.Header.Get("Authorization") != "Bearer registry-token" {
+			http.Error(w, "unexpected headers", http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/swift/mona/LinkedList":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"releases": {
+				"1.1.1": {"url": "https://packages.example.com/swift/mona/LinkedList/1.1.1"},
+				"1.2.0": {"url": "https://packages.example.com/swift/mona/LinkedList/1.2.0", "problem": {"status": 410, "title": "Gone", "detail": "this release was removed from the registry"}},
+				"2.0.0-beta.1": {"url": "https://packages.example.com/swift/mona/LinkedList/2.0.0-beta.1"},
+				"1.0.0": {"url": "https://packages.example.com/swift/mona/LinkedList/1.0.0"}
+			}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SWIFT_REGISTRY_URL", server.URL+"/swift/")
+	t.Setenv("SWIFT_REGISTRY_TOKEN", "registry-token")
+	handler := NewSwiftHandler(logger, &sync.Map{})
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"id": "mona.LinkedList", "requirement": `exact: "1.0.0"`},
+			map[string]interface{}{"id": "mona.Missing", "version": "1.0.0"},
+			map[string]interface{}{"id": "not-an-identity", "version": "1.0.0"},
+			map[string]interface{}{"url": "Alamofire.git", "version": "1.0.0"},
+		},
+	})
+	require.NoError(t, err)
+
+	var results []SwiftPackageVersion
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 4)
+
+	// Only ids are looked up in the registry, so anything given as the url is a repository
+	assert.Equal(t, "Alamofire.git", results[0].Name)
+	assert.True(t, results[0].Skipped)
+	assert.Contains(t, results[0].SkipReason, "invalid repository URL")
+
+	// Removed releases and pre-releases are left out
+	assert.Equal(t, "mona.LinkedList", results[1].Name)
+	assert.Equal(t, "1.1.1", results[1].LatestVersion)
+	require.NotNil(t, results[1].LatestAllowed)
+	assert.Equal(t, "1.0.0", *results[1].LatestAllowed)
+	assert.True(t, results[1].UpdateRequiresConstraintChange)
+
+	assert.Equal(t, "mona.Missing", results[2].Name)
+	assert.True(t, results[2].Skipped)
+	assert.Contains(t, results[2].SkipReason, "failed to fetch Swift registry releases")
+
+	assert.Equal(t, "not-an-identity", results[3].Name)
+	assert.True(t, results[3].Skipped)
+	assert.Contains(t, results[3].SkipReason, "invalid Swift package registry identity")
+
+	// The token isn't sent to a registry given as an argument
+	var otherAuthorization []string
+	otherServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherAuthorization = append(otherAuthorization, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"releases": {"1.0.0": {}}}`))
+	}))
+	defer otherServer.Close()
+
+	result, err = handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"registry": otherServer.URL,
+		"dependencies": []interface{}{
+			map[string]interface{}{"id": "mona.LinkedList", "version": "1.0.0"},
+		},
+	})
+	require.NoError(t, err)
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 1)
+	assert.Equal(t, "1.0.0", results[0].LatestVersion)
+	assert.Equal(t, []string{""}, otherAuthorization)
+
+	// Without a configured registry, identities can't be resolved
+	t.Setenv("SWIFT_REGISTRY_URL", "")
+	result, err = NewSwiftHandler(logger, &sync.Map{}).GetLatestVersion(context.Background(), map[string]interface{}{
+		"dependencies": []interface{}{
+			map[string]interface{}{"id": "mona.LinkedList", "version": "1.0.0"},
+		},
+	})
+	require.NoError(t, err)
+	unmarshalToolResult(t, result, &results)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Skipped)
+	assert.Contains(t, results[0].SkipReason, "no Swift package registry configured")
+}
@@ -0,0 +1,479 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// TerraformRegistryHost is the default host for the public Terraform registry
+	TerraformRegistryHost = "registry.terraform.io"
+)
+
+// TerraformHandler handles Terraform provider and module version checking
+type TerraformHandler struct {
+	client HTTPClient
+	cache  *sync.Map
+	logger *logrus.Logger
+	// scheme is the URL scheme used for registry requests, only overridden in tests
+	scheme string
+}
+
+// NewTerraformHandler creates a new Terraform handler
+func NewTerraformHandler(logger *logrus.Logger, cache *sync.Map) *TerraformHandler {
+	if cache == nil {
+		cache = &sync.Map{}
+	}
+	return &TerraformHandler{
+		client: DefaultHTTPClient,
+		cache:  cache,
+		logger: logger,
+		scheme: "https",
+	}
+}
+
+// TerraformServiceDiscovery represents a registry's .well-known/terraform.json document
+type TerraformServiceDiscovery struct {
+	ProvidersV1 string `json:"providers.v1"`
+	ModulesV1   string `json:"modules.v1"`
+}
+
+// TerraformProviderVersionsResponse represents a response from the providers v1 versions endpoint
+type TerraformProviderVersionsResponse struct {
+	Versions []struct {
+		Version string `json:"version"`
+	} `json:"versions"`
+}
+
+// TerraformModuleVersionsResponse represents a response from the modules v1 versions endpoint
+type TerraformModuleVersionsResponse struct {
+	Modules []struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"modules"`
+}
+
+// discoverServices resolves the provider and module API paths for a registry host,
+// falling back to the standard v1 paths if discovery fails
+func (h *TerraformHandler) discoverServices(host string) TerraformServiceDiscovery {
+	defaults := TerraformServiceDiscovery{
+		ProvidersV1: "/v1/providers/",
+		ModulesV1:   "/v1/modules/",
+	}
+
+	// Check cache first
+	cacheKey := fmt.Sprintf("terraform-discovery:%s", host)
+	if cachedServices, ok := h.cache.Load(cacheKey); ok {
+		return cachedServices.(TerraformServiceDiscovery)
+	}
+
+	discoveryURL := fmt.Sprintf("%s://%s/.well-known/terraform.json", h.scheme, host)
+	h.logger.WithField("url", discoveryURL).Debug("Fetching Terraform service discovery document")
+
+	services := defaults
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", discoveryURL, nil)
+	if err == nil {
+		var discovered TerraformServiceDiscovery
+		if err := json.Unmarshal(body, &discovered); err == nil {
+			if discovered.ProvidersV1 != "" {
+				services.ProvidersV1 = discovered.ProvidersV1
+			}
+			if discovered.ModulesV1 != "" {
+				services.ModulesV1 = discovered.ModulesV1
+			}
+		}
+	} else {
+		h.logger.WithFields(logrus.Fields{
+			"host":  host,
+			"error": err.Error(),
+		}).Debug("Terraform service discovery failed, using default paths")
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, services)
+
+	return services
+}
+
+// serviceURL joins a discovered service path onto the registry host
+func (h *TerraformHandler) serviceURL(host, servicePath, path string) string {
+	if !strings.HasPrefix(servicePath, "http://") && !strings.HasPrefix(servicePath, "https://") {
+		servicePath = fmt.Sprintf("%s://%s/%s", h.scheme, host, strings.TrimPrefix(servicePath, "/"))
+	}
+	return strings.TrimSuffix(servicePath, "/") + "/" + path
+}
+
+// getProviderVersions gets all published versions of a provider
+func (h *TerraformHandler) getProviderVersions(host, namespace, providerType string) ([]string, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("terraform-provider:%s/%s/%s", host, namespace, providerType)
+	if cachedVersions, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("provider", cacheKey).Debug("Using cached Terraform provider versions")
+		return cachedVersions.([]string), nil
+	}
+
+	services := h.discoverServices(host)
+	versionsURL := h.serviceURL(host, services.ProvidersV1, fmt.Sprintf("%s/%s/versions", namespace, providerType))
+	h.logger.WithFields(logrus.Fields{
+		"provider": fmt.Sprintf("%s/%s", namespace, providerType),
+		"url":      versionsURL,
+	}).Debug("Fetching Terraform provider versions")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", versionsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Terraform provider versions: %w", err)
+	}
+
+	// Parse response
+	var response TerraformProviderVersionsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Terraform provider versions: %w", err)
+	}
+
+	versions := make([]string, 0, len(response.Versions))
+	for _, version := range response.Versions {
+		versions = append(versions, version.Version)
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, versions)
+
+	return versions, nil
+}
+
+// getModuleVersions gets all published versions of a module
+func (h *TerraformHandler) getModuleVersions(host, namespace, name, provider string) ([]string, error) {
+	// Check cache first
+	cacheKey := fmt.Sprintf("terraform-module:%s/%s/%s/%s", host, namespace, name, provider)
+	if cachedVersions, ok := h.cache.Load(cacheKey); ok {
+		h.logger.WithField("module", cacheKey).Debug("Using cached Terraform module versions")
+		return cachedVersions.([]string), nil
+	}
+
+	services := h.discoverServices(host)
+	versionsURL := h.serviceURL(host, services.ModulesV1, fmt.Sprintf("%s/%s/%s/versions", namespace, name, provider))
+	h.logger.WithFields(logrus.Fields{
+		"module": fmt.Sprintf("%s/%s/%s", namespace, name, provider),
+		"url":    versionsURL,
+	}).Debug("Fetching Terraform module versions")
+
+	// Make request
+	body, err := MakeRequestWithLogger(h.client, h.logger, "GET", versionsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Terraform module versions: %w", err)
+	}
+
+	// Parse response
+	var response TerraformModuleVersionsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Terraform module versions: %w", err)
+	}
+
+	var versions []string
+	for _, module := range response.Modules {
+		for _, version := range module.Versions {
+			versions = append(versions, version.Version)
+		}
+	}
+
+	// Cache result
+	h.cache.Store(cacheKey, versions)
+
+	return versions, nil
+}
+
+// splitTerraformSource splits a provider or module source address into its registry host
+// and remaining path segments. Sources without a hostname use the default host.
+func splitTerraformSource(source, defaultHost string, segments int) (host string, parts []string, err error) {
+	parts = strings.Split(strings.Trim(source, "/"), "/")
+	host = defaultHost
+
+	// A leading segment containing a dot or colon is a hostname
+	if len(parts) == segments+1 && strings.ContainsAny(parts[0], ".:") {
+		host = parts[0]
+		parts = parts[1:]
+	}
+
+	if len(parts) != segments {
+		return "", nil, fmt.Errorf("invalid source address: %s", source)
+	}
+
+	return host, parts, nil
+}
+
+// isTerraformRegistryModuleSource reports whether a module source refers to a registry
+// rather than a local path, VCS repository, archive or other remote source
+func isTerraformRegistryModuleSource(source string) bool {
+	for _, prefix := range []string{"./", "../", "/", "git@", "github.com/", "bitbucket.org/", "http://", "https://", "s3::", "gcs::"} {
+		if strings.HasPrefix(source, prefix) {
+			return false
+		}
+	}
+	return !strings.ContainsAny(source, "?") && !strings.Contains(source, "::")
+}
+
+// terraformConstraintAllows reports whether a version satisfies a Terraform version
+// constraint such as "~> 5.0" or ">= 4.0, < 6.0"
+func terraformConstraintAllows(version, constraint string) bool {
+	for _, clause := range strings.Split(constraint, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+
+		operator := "="
+		for _, op := range []string{"~>", ">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(clause, op) {
+				operator = op
+				clause = strings.TrimSpace(strings.TrimPrefix(clause, op))
+				break
+			}
+		}
+
+		result, err := CompareVersions(version, clause)
+		if err != nil {
+			return false
+		}
+
+		switch operator {
+		case "=":
+			if result != 0 {
+				return false
+			}
+		case "!=":
+			if result == 0 {
+				return false
+			}
+		case ">":
+			if result <= 0 {
+				return false
+			}
+		case ">=":
+			if result < 0 {
+				return false
+			}
+		case "<":
+			if result >= 0 {
+				return false
+			}
+		case "<=":
+			if result > 0 {
+				return false
+			}
+		case "~>":
+			// Only the rightmost specified segment may increase
+			if result < 0 {
+				return false
+			}
+			segments := strings.Split(strings.TrimPrefix(clause, "v"), ".")
+			versionSegments := strings.Split(strings.TrimPrefix(version, "v"), ".")
+			for i := 0; i < len(segments)-1; i++ {
+				if i >= len(versionSegments) || versionSegments[i] != segments[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// GetLatestVersion gets the latest versions of Terraform providers and modules
+func (h *TerraformHandler) GetLatestVersion(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	h.logger.Debug("Getting latest Terraform provider and module versions")
+
+	// Parse registry host
+	defaultHost := TerraformRegistryHost
+	if registryRaw, ok := args["registry"].(string); ok && registryRaw != "" {
+		defaultHost = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registryRaw, "https://"), "http://"), "/")
+	}
+
+	// Parse providers and modules
+	providers, err := parseTerraformRequirements(args["providers"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid providers format: %w", err)
+	}
+	modules, err := parseTerraformRequirements(args["modules"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid modules format: %w", err)
+	}
+	if len(providers) == 0 && len(modules) == 0 {
+		return nil, fmt.Errorf("missing required parameter: providers or modules")
+	}
+
+	// Parse constraints
+	constraints := parseVersionConstraints(args)
+
+	results := make([]TerraformVersion, 0, len(providers)+len(modules))
+
+	// Process each provider
+	for _, provider := range providers {
+		source := provider.Source
+		// Legacy unqualified provider sources default to the hashicorp namespace
+		if !strings.Contains(source, "/") {
+			source = "hashicorp/" + source
+		}
+		name := provider.Name
+		if name == "" {
+			name = provider.Source
+		}
+
+		host, parts, err := splitTerraformSource(source, defaultHost, 2)
+		if err != nil {
+			results = append(results, h.skippedResult(name, provider.Version, defaultHost, err.Error()))
+			continue
+		}
+
+		results = append(results, h.processRequirement(name, provider.Version, host, constraints, func() ([]string, error) {
+			return h.getProviderVersions(host, parts[0], parts[1])
+		}))
+	}
+
+	// Process each module
+	for _, module := range modules {
+		name := module.Name
+		if name == "" {
+			name = module.Source
+		}
+
+		host, parts, err := splitTerraformSource(module.Source, defaultHost, 3)
+		if err != nil || !isTerraformRegistryModuleSource(module.Source) {
+			results = append(results, h.skippedResult(name, module.Version, defaultHost,
+				fmt.Sprintf("Only registry module sources (namespace/name/provider) are supported: %s", module.Source)))
+			continue
+		}
+
+		results = append(results, h.processRequirement(name, module.Version, host, constraints, func() ([]string, error) {
+			return h.getModuleVersions(host, parts[0], parts[1], parts[2])
+		}))
+	}
+
+	// Sort results by name
+	sort.Slice(results, func(i, j int) bool {
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	return NewToolResultJSON(results)
+}
+
+// parseTerraformRequirements converts a providers or modules argument into TerraformRequirements
+func parseTerraformRequirements(raw interface{}) ([]TerraformRequirement, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	arr, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected array")
+	}
+
+	var requirements []TerraformRequirement
+	for _, itemRaw := range arr {
+		if itemMap, ok := itemRaw.(map[string]interface{}); ok {
+			var requirement TerraformRequirement
+			if source, ok := itemMap["source"].(string); ok && source != "" {
+				requirement.Source = source
+			} else {
+				continue
+			}
+			if name, ok := itemMap["name"].(string); ok {
+				requirement.Name = name
+			}
+			if version, ok := itemMap["version"].(string); ok {
+				requirement.Version = version
+			}
+			requirements = append(requirements, requirement)
+		}
+	}
+
+	return requirements, nil
+}
+
+// skippedResult builds a result for a requirement that could not be checked
+func (h *TerraformHandler) skippedResult(name, version, host, reason string) TerraformVersion {
+	return TerraformVersion{
+		PackageVersion: PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(version),
+			LatestVersion:  "unknown",
+			Registry:       host,
+			Skipped:        true,
+			SkipReason:     reason,
+		},
+	}
+}
+
+// processRequirement resolves the latest and latest allowed versions for a provider or module
+func (h *TerraformHandler) processRequirement(name, version, host string, constraints VersionConstraints, fetch func() ([]string, error)) TerraformVersion {
+	h.logger.WithFields(logrus.Fields{
+		"name":    name,
+		"version": version,
+		"host":    host,
+	}).Debug("Processing Terraform requirement")
+
+	// Check if requirement should be excluded
+	if constraint, ok := constraints[name]; ok && constraint.ExcludePackage {
+		return TerraformVersion{
+			PackageVersion: PackageVersion{
+				Name:       name,
+				Skipped:    true,
+				SkipReason: "Package excluded by constraints",
+			},
+		}
+	}
+
+	versions, err := fetch()
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"name":  name,
+			"error": err.Error(),
+		}).Error("Failed to get Terraform registry versions")
+		return h.skippedResult(name, version, host, fmt.Sprintf("Failed to fetch versions: %v", err))
+	}
+
+	// Apply major version constraint if specified
+	var majorVersion *int
+	if constraint, ok := constraints[name]; ok {
+		majorVersion = constraint.MajorVersion
+	}
+
+	result := TerraformVersion{
+		PackageVersion: PackageVersion{
+			Name:           name,
+			CurrentVersion: StringPtr(version),
+			LatestVersion:  FindLatestVersion(versions, majorVersion),
+			Registry:       host,
+		},
+	}
+	if result.LatestVersion == "" {
+		result.LatestVersion = "unknown"
+		result.Skipped = true
+		result.SkipReason = "No stable versions found"
+		return result
+	}
+
+	// Report the newest version the current constraint still accepts
+	if version != "" {
+		var allowed []string
+		for _, v := range versions {
+			if terraformConstraintAllows(v, version) {
+				allowed = append(allowed, v)
+			}
+		}
+		if latestAllowed := FindLatestVersion(allowed, majorVersion); latestAllowed != "" {
+			result.LatestAllowed = StringPtr(latestAllowed)
+		}
+		result.UpdateRequiresConstraintChange = result.LatestAllowed == nil || *result.LatestAllowed != result.LatestVersion
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformConstraintAllows(t *testing.T) {
+	assert.True(t, terraformConstraintAllows("5.31.0", "~> 5.0"))
+	assert.False(t, terraformConstraintAllows("6.0.0", "~> 5.0"))
+	assert.True(t, terraformConstraintAllows("5.0.9", "~> 5.0.1"))
+	assert.False(t, terraformConstraintAllows("5.1.0", "~> 5.0.1"))
+	assert.True(t, terraformConstraintAllows("3.6.0", ">= 3.0, < 4.0"))
+	assert.False(t, terraformConstraintAllows("4.0.0", ">= 3.0, < 4.0"))
+	assert.True(t, terraformConstraintAllows("1.2.3", "1.2.3"))
+	assert.False(t, terraformConstraintAllows("1.2.3", "!= 1.2.3"))
+}
+
+func TestTerraformHandler_GetLatestVersion(t *testing.T) {
+	// Create a logger for testing
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Stand-in registry using non-default service paths
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/terraform.json":
+			_, _ = w.Write([]byte(`{"providers.v1": "/api/providers/", "modules.v1": "/api/modules/"}`))
+		case "/api/providers/hashicorp/aws/versions":
+			_, _ = w.Write([]byte(`{"versions": [{"version": "4.67.0"}, {"version": "5.31.0"}, {"version": "6.0.0"}, {"version": "6.1.0-beta1"}]}`))
+		case "/api/modules/terraform-aws-modules/vpc/aws/versions":
+			_, _ = w.Write([]byte(`{"modules": [{"versions": [{"version": "5.4.0"}, {"version": "5.5.1"}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	handler := NewTerraformHandler(logger, &sync.Map{})
+	handler.scheme = "http"
+
+	result, err := handler.GetLatestVersion(context.Background(), map[string]interface{}{
+		"registry": strings.TrimPrefix(server.URL, "http://"),
+		"providers": []interface{}{
+			map[string]interface{}{"name": "aws", "source": "hashicorp/aws", "version": "~> 5.0"},
+		},
+		"modules": []interface{}{
+			map[string]interface{}{"name": "vpc", "source": "terraform-aws-modules/vpc/aws", "version": "~> 5.5"},
+			map[string]interface{}{"name": "local", "source": "./modules/local"},
+		},
+	})
+	require.NoError(t, err)
+
+	var versions []TerraformVersion
+	unmarshalToolResult(t, result, &versions)
+	require.Len(t, versions, 3)
+
+	assert.Equal(t, "aws", versions[0].Name)
+	assert.Equal(t, "6.0.0", versions[0].LatestVersion)
+	require.NotNil(t, versions[0].LatestAllowed)
+	assert.Equal(t, "5.31.0", *versions[0].LatestAllowed)
+	assert.True(t, versions[0].UpdateRequiresConstraintChange)
+
+	assert.Equal(t, "local", versions[1].Name)
+	assert.True(t, versions[1].Skipped)
+	assert.Contains(t, versions[1].SkipReason, "Only registry module sources")
+
+	assert.Equal(t, "vpc", versions[2].Name)
+	assert.Equal(t, "5.5.1", versions[2].LatestVersion)
+	assert.False(t, versions[2].UpdateRequiresConstraintChange)
+}
@@ -67,11 +67,114 @@ type GoReplace struct {
 
 // SwiftDependency represents a dependency in a Swift Package.swift file
 type SwiftDependency struct {
-	URL         string `json:"url"`
+	URL string `json:"url,omitempty"`
+	// ID is a Swift Package Registry identity such as mona.LinkedList
+	ID          string `json:"id,omitempty"`
 	Version     string `json:"version,omitempty"`
 	Requirement string `json:"requirement,omitempty"`
 }
 
+// SwiftPackageVersion represents version information for a Swift package
+type SwiftPackageVersion struct {
+	PackageVersion
+	// RequirementKind is the kind of Package.swift requirement, such as upToNextMajor or exact
+	RequirementKind                string  `json:"requirementKind,omitempty"`
+	LatestAllowed                  *string `json:"latestAllowed,omitempty"`
+	UpdateRequiresConstraintChange bool    `json:"updateRequiresConstraintChange,omitempty"`
+	// Archived and DefaultBranch are reported when the repository was loaded with GraphQL
+	Archived      bool    `json:"archived,omitempty"`
+	DefaultBranch *string `json:"defaultBranch,omitempty"`
+}
+
+// CargoDependency represents a dependency in a Rust Cargo.toml file
+type CargoDependency struct {
+	Name      string   `json:"name"`
+	Package   string   `json:"package,omitempty"`
+	Version   string   `json:"version,omitempty"`
+	Features  []string `json:"features,omitempty"`
+	Optional  bool     `json:"optional,omitempty"`
+	Path      string   `json:"path,omitempty"`
+	Git       string   `json:"git,omitempty"`
+	Workspace bool     `json:"workspace,omitempty"`
+}
+
+// RubyGem represents a gem entry in a Ruby Gemfile
+type RubyGem struct {
+	Name        string `json:"name"`
+	Requirement string `json:"requirement,omitempty"`
+	Group       string `json:"group,omitempty"`
+}
+
+// NuGetPackageReference represents a PackageReference in a .csproj or Directory.Packages.props file
+type NuGetPackageReference struct {
+	ID              string `json:"id"`
+	Version         string `json:"version,omitempty"`
+	TargetFramework string `json:"targetFramework,omitempty"`
+}
+
+// MixDependency represents a dependency tuple in an Elixir mix.exs file
+type MixDependency struct {
+	Name        string            `json:"name"`
+	Requirement string            `json:"requirement,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+}
+
+// HexRetirement represents the retirement status of a Hex package release
+type HexRetirement struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// HexPackageVersion represents version information for a Hex package
+type HexPackageVersion struct {
+	PackageVersion
+	Retired *HexRetirement `json:"retired,omitempty"`
+}
+
+// PubspecDependency represents a dependency in a Dart/Flutter pubspec.yaml file
+type PubspecDependency struct {
+	Name         string `json:"name"`
+	Version      string `json:"version,omitempty"`
+	Source       string `json:"source,omitempty"`
+	SourceDetail string `json:"sourceDetail,omitempty"`
+	HostedURL    string `json:"hostedUrl,omitempty"`
+}
+
+// PubPackageVersion represents version information for a Dart/Flutter package
+type PubPackageVersion struct {
+	PackageVersion
+	Discontinued bool    `json:"discontinued,omitempty"`
+	ReplacedBy   *string `json:"replacedBy,omitempty"`
+}
+
+// TerraformRequirement represents a required_providers entry or module block in Terraform configuration
+type TerraformRequirement struct {
+	Name    string `json:"name,omitempty"`
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+}
+
+// TerraformVersion represents version information for a Terraform provider or module
+type TerraformVersion struct {
+	PackageVersion
+	LatestAllowed                  *string `json:"latestAllowed,omitempty"`
+	UpdateRequiresConstraintChange bool    `json:"updateRequiresConstraintChange,omitempty"`
+}
+
+// HelmChartDependency represents a dependency in a Helm Chart.yaml file
+type HelmChartDependency struct {
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+	Repository string `json:"repository,omitempty"`
+}
+
+// HelmChartVersion represents version information for a Helm chart
+type HelmChartVersion struct {
+	PackageVersion
+	AppVersion *string `json:"appVersion,omitempty"`
+	Deprecated bool    `json:"deprecated,omitempty"`
+}
+
 // BedrockModel represents an AWS Bedrock model
 type BedrockModel struct {
 	Provider           string   `json:"provider"`
@@ -91,12 +194,51 @@ type BedrockModelSearchResult struct {
 
 // DockerImageVersion represents version information for a Docker image
 type DockerImageVersion struct {
-	Name     string  `json:"name"`
-	Tag      string  `json:"tag"`
-	Registry string  `json:"registry"`
-	Digest   *string `json:"digest,omitempty"`
-	Created  *string `json:"created,omitempty"`
-	Size     *string `json:"size,omitempty"`
+	Name      string                `json:"name"`
+	Tag       string                `json:"tag"`
+	Registry  string                `json:"registry"`
+	Digest    *string               `json:"digest,omitempty"`
+	Created   *string               `json:"created,omitempty"`
+	Size      *string               `json:"size,omitempty"`
+	Variant   *string               `json:"variant,omitempty"`
+	Platforms []DockerImagePlatform `json:"platforms,omitempty"`
+}
+
+// DockerImagePlatform represents the image for a single platform of a multi-arch tag
+type DockerImagePlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+	Digest       string `json:"digest,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+}
+
+// DockerTagRecommendation compares a current Docker image tag with the newest tag of the
+// same variant and precision
+type DockerTagRecommendation struct {
+	PackageVersion
+	Variant         string                `json:"variant"`
+	Precision       int                   `json:"precision"`
+	UpdateAvailable bool                  `json:"updateAvailable"`
+	Digest          *string               `json:"digest,omitempty"`
+	Created         *string               `json:"created,omitempty"`
+	Platforms       []DockerImagePlatform `json:"platforms,omitempty"`
+}
+
+// DockerImageUpdate reports the newest compatible tag for an image reference found in a
+// Dockerfile, compose file or Kubernetes manifest
+type DockerImageUpdate struct {
+	PackageVersion
+	Reference       string  `json:"reference"`
+	CurrentDigest   *string `json:"currentDigest,omitempty"`
+	LatestDigest    *string `json:"latestDigest,omitempty"`
+	UpdateAvailable bool    `json:"updateAvailable"`
+	Platform        string  `json:"platform,omitempty"`
+	Stage           string  `json:"stage,omitempty"`
+	File            string  `json:"file,omitempty"`
+	Resource        string  `json:"resource,omitempty"`
+	Location        string  `json:"location,omitempty"`
+	Line            int     `json:"line,omitempty"`
 }
 
 // DockerImageQuery represents a query for Docker image tags
@@ -107,6 +249,10 @@ type DockerImageQuery struct {
 	Limit          int      `json:"limit,omitempty"`
 	FilterTags     []string `json:"filterTags,omitempty"`
 	IncludeDigest  bool     `json:"includeDigest,omitempty"`
+	Sort           string   `json:"sort,omitempty"`
+	MaxPages       int      `json:"maxPages,omitempty"`
+	Platforms      bool     `json:"platforms,omitempty"`
+	Platform       string   `json:"platform,omitempty"`
 }
 
 // GitHubAction represents a GitHub Action
@@ -124,4 +270,45 @@ type GitHubActionVersion struct {
 	LatestVersion  string  `json:"latestVersion"`
 	PublishedAt    *string `json:"publishedAt,omitempty"`
 	URL            *string `json:"url,omitempty"`
+	// LatestExactTag and LatestMajorTag are the newest vX.Y.Z and floating vX tags
+	LatestExactTag *string `json:"latestExactTag,omitempty"`
+	LatestMajorTag *string `json:"latestMajorTag,omitempty"`
+	// BehindMajor is set when the current version is behind a newer major version
+	BehindMajor bool `json:"behindMajor,omitempty"`
+	// LatestSHA is the commit the latest version tag points to
+	LatestSHA  *string `json:"latestSha,omitempty"`
+	PinnedUses *string `json:"pinnedUses,omitempty"`
+	// CurrentSHATags lists the tags pointing at a currently pinned commit SHA
+	CurrentSHATags     []string `json:"currentShaTags,omitempty"`
+	CurrentSHAVerified *bool    `json:"currentShaVerified,omitempty"`
+	// CurrentRuntime and LatestRuntime are the runs.using values of action.yml at each ref
+	CurrentRuntime          *string                  `json:"currentRuntime,omitempty"`
+	LatestRuntime           *string                  `json:"latestRuntime,omitempty"`
+	DeprecatedRuntime       bool                     `json:"deprecatedRuntime,omitempty"`
+	LatestDeprecatedRuntime bool                     `json:"latestDeprecatedRuntime,omitempty"`
+	CompositeUses           []GitHubActionDependency `json:"compositeUses,omitempty"`
+	// Host is the forge the action is hosted on when it isn't github.com
+	Host string `json:"host,omitempty"`
+	// Archived and DefaultBranch are reported when the repository was loaded with GraphQL
+	Archived      bool    `json:"archived,omitempty"`
+	DefaultBranch *string `json:"defaultBranch,omitempty"`
+}
+
+// GitHubActionDependency represents an action used by a step of a composite action
+type GitHubActionDependency struct {
+	Uses              string                   `json:"uses"`
+	Runtime           string                   `json:"runtime,omitempty"`
+	DeprecatedRuntime bool                     `json:"deprecatedRuntime,omitempty"`
+	CompositeUses     []GitHubActionDependency `json:"compositeUses,omitempty"`
+}
+
+// GitHubActionReference reports the latest version for a `uses:` reference such as
+// actions/checkout@v4, github/codeql-action/init@v3 or a reusable workflow
+type GitHubActionReference struct {
+	GitHubActionVersion
+	Path             string   `json:"path,omitempty"`
+	ReusableWorkflow bool     `json:"reusableWorkflow,omitempty"`
+	Locations        []string `json:"locations,omitempty"`
+	Skipped          bool     `json:"skipped,omitempty"`
+	SkipReason       string   `json:"skipReason,omitempty"`
 }
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -33,6 +35,26 @@ func MakeRequest(client HTTPClient, method, url string, headers map[string]strin
 
 // MakeRequestWithLogger makes an HTTP request with logging and returns the response body
 func MakeRequestWithLogger(client HTTPClient, logger *logrus.Logger, method, url string, headers map[string]string) ([]byte, error) {
+	return MakeRequestWithBody(client, logger, method, url, headers, nil)
+}
+
+// MakeRequestWithBody makes an HTTP request with a request body and logging, returning the
+// response body
+func MakeRequestWithBody(client HTTPClient, logger *logrus.Logger, method, url string, headers map[string]string, requestBody []byte) ([]byte, error) {
+	body, _, err := makeRequest(client, logger, method, url, headers, requestBody, false)
+	return body, err
+}
+
+// makeRateLimitedRequest makes an HTTP request like MakeRequestWithBody, returning a
+// RateLimitError when the API reports an exhausted rate limit the way GitHub does, and
+// the response headers on success
+func makeRateLimitedRequest(client HTTPClient, logger *logrus.Logger, method, url string, headers map[string]string, requestBody []byte) ([]byte, http.Header, error) {
+	return makeRequest(client, logger, method, url, headers, requestBody, true)
+}
+
+// makeRequest makes an HTTP request, optionally translating rate limit responses into a
+// RateLimitError, and returns the response body and headers
+func makeRequest(client HTTPClient, logger *logrus.Logger, method, url string, headers map[string]string, requestBody []byte, checkRateLimit bool) ([]byte, http.Header, error) {
 	if logger != nil {
 		logger.WithFields(logrus.Fields{
 			"method": method,
@@ -40,7 +62,11 @@ func MakeRequestWithLogger(client HTTPClient, logger *logrus.Logger, method, url
 		}).Debug("Making HTTP request")
 	}
 
-	req, err := http.NewRequest(method, url, nil)
+	var bodyReader io.Reader
+	if requestBody != nil {
+		bodyReader = bytes.NewReader(requestBody)
+	}
+	req, err := http.NewRequest(method, url, bodyReader)
 	if err != nil {
 		if logger != nil {
 			logger.WithFields(logrus.Fields{
@@ -49,7 +75,7 @@ func MakeRequestWithLogger(client HTTPClient, logger *logrus.Logger, method, url
 				"error":  err.Error(),
 			}).Error("Failed to create request")
 		}
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -75,7 +101,7 @@ func MakeRequestWithLogger(client HTTPClient, logger *logrus.Logger, method, url
 				"error":  err.Error(),
 			}).Error("Failed to send request")
 		}
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer func() {
 		err := resp.Body.Close()
@@ -98,10 +124,23 @@ func MakeRequestWithLogger(client HTTPClient, logger *logrus.Logger, method, url
 				"error":  err.Error(),
 			}).Error("Failed to read response body")
 		}
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Check for errors
+	// Check for errors, reporting exhausted rate limits separately
+	if checkRateLimit {
+		if rateLimitErr := parseRateLimitError(resp); rateLimitErr != nil {
+			if logger != nil {
+				logger.WithFields(logrus.Fields{
+					"method":     method,
+					"url":        url,
+					"statusCode": resp.StatusCode,
+					"resetAt":    rateLimitErr.ResetAt,
+				}).Error("Rate limit exceeded")
+			}
+			return nil, nil, rateLimitErr
+		}
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		if logger != nil {
 			logger.WithFields(logrus.Fields{
@@ -111,7 +150,7 @@ func MakeRequestWithLogger(client HTTPClient, logger *logrus.Logger, method, url
 				"body":       string(body),
 			}).Error("Unexpected status code")
 		}
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+		return nil, nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
 	}
 
 	if logger != nil {
@@ -120,9 +159,116 @@ func MakeRequestWithLogger(client HTTPClient, logger *logrus.Logger, method, url
 			"url":        url,
 			"statusCode": resp.StatusCode,
 		}).Debug("HTTP request completed successfully")
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			logger.WithFields(logrus.Fields{
+				"url":   url,
+				"reset": resp.Header.Get("X-RateLimit-Reset"),
+			}).Warn("Rate limit exhausted")
+		}
 	}
 
-	return body, nil
+	return body, resp.Header, nil
+}
+
+// RateLimitError is returned when an API rejects a request because a rate limit has been
+// exhausted, reporting when requests may be retried
+type RateLimitError struct {
+	Host       string     `json:"host"`
+	StatusCode int        `json:"statusCode"`
+	Limit      *int       `json:"limit,omitempty"`
+	Remaining  *int       `json:"remaining,omitempty"`
+	ResetAt    *time.Time `json:"resetAt,omitempty"`
+	RetryAfter *int       `json:"retryAfterSeconds,omitempty"`
+}
+
+// Error describes the exhausted rate limit and when it resets
+func (e *RateLimitError) Error() string {
+	message := fmt.Sprintf("rate limit exceeded for %s (status %d)", e.Host, e.StatusCode)
+	if e.Limit != nil && e.Remaining != nil {
+		message += fmt.Sprintf(", %d of %d requests remaining", *e.Remaining, *e.Limit)
+	}
+	if e.ResetAt != nil {
+		message += fmt.Sprintf(", resets at %s", e.ResetAt.UTC().Format(time.RFC3339))
+	}
+	return message
+}
+
+// parseRateLimitError returns a RateLimitError for 429 responses and for 403 responses that
+// report an exhausted X-RateLimit-Remaining or a Retry-After delay, as GitHub does
+func parseRateLimitError(resp *http.Response) *RateLimitError {
+	remaining := resp.Header.Get("X-RateLimit-Remaining")
+	retryAfter := resp.Header.Get("Retry-After")
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode == http.StatusForbidden && (remaining == "0" || retryAfter != ""):
+	default:
+		return nil
+	}
+
+	host := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		host = resp.Request.URL.Host
+	}
+	return newRateLimitError(host, resp.StatusCode, resp.Header)
+}
+
+// newRateLimitError creates a RateLimitError from the X-RateLimit-* and Retry-After headers
+// of a response
+func newRateLimitError(host string, statusCode int, header http.Header) *RateLimitError {
+	remaining := header.Get("X-RateLimit-Remaining")
+	retryAfter := header.Get("Retry-After")
+	rateLimitErr := &RateLimitError{
+		Host:       host,
+		StatusCode: statusCode,
+	}
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		rateLimitErr.Limit = &limit
+	}
+	if remaining, err := strconv.Atoi(remaining); err == nil {
+		rateLimitErr.Remaining = &remaining
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		resetAt := time.Unix(reset, 0).UTC()
+		rateLimitErr.ResetAt = &resetAt
+	}
+
+	// Retry-After is either a number of seconds or an HTTP date
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			rateLimitErr.RetryAfter = &seconds
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			seconds := int(time.Until(date).Seconds())
+			rateLimitErr.RetryAfter = &seconds
+		}
+		if rateLimitErr.RetryAfter != nil && rateLimitErr.ResetAt == nil {
+			resetAt := time.Now().Add(time.Duration(*rateLimitErr.RetryAfter) * time.Second).UTC().Truncate(time.Second)
+			rateLimitErr.ResetAt = &resetAt
+		}
+	}
+
+	return rateLimitErr
+}
+
+// NewToolResultRateLimitError creates an error tool result describing an exhausted rate
+// limit, so clients can tell when to retry rather than receiving unknown versions
+func NewToolResultRateLimitError(rateLimitErr *RateLimitError) (*mcp.CallToolResult, error) {
+	result, err := NewToolResultJSON(map[string]interface{}{
+		"error":     rateLimitErr.Error(),
+		"rateLimit": rateLimitErr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.IsError = true
+	return result, nil
+}
+
+// GitHubTokenFromEnv returns a GitHub token from the GITHUB_TOKEN or GH_TOKEN environment variables
+func GitHubTokenFromEnv() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	return os.Getenv("GH_TOKEN")
 }
 
 // NewToolResultJSON creates a new tool result with JSON content
@@ -222,12 +368,200 @@ func CompareVersions(v1, v2 string) (int, error) {
 	return 0, nil
 }
 
+// IsPrereleaseVersion reports whether a version string carries a pre-release identifier
+// (e.g. "1.0.0-beta.1", "7.1.0.rc1")
+func IsPrereleaseVersion(version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	version = strings.TrimPrefix(version, "V")
+
+	// Build metadata does not make a version a pre-release
+	if idx := strings.Index(version, "+"); idx != -1 {
+		version = version[:idx]
+	}
+
+	if strings.Contains(version, "-") {
+		return true
+	}
+
+	// Some ecosystems (e.g. RubyGems) use letters instead of a hyphen
+	for _, r := range version {
+		if (r < '0' || r > '9') && r != '.' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindLatestVersion returns the highest stable version from a list of versions,
+// optionally restricted to the given major version. Returns an empty string if no
+// suitable version is found.
+func FindLatestVersion(versions []string, majorVersion *int) string {
+	var latest string
+	for _, version := range versions {
+		if IsPrereleaseVersion(version) {
+			continue
+		}
+
+		major, _, _, err := ParseVersion(version)
+		if err != nil {
+			continue
+		}
+		if majorVersion != nil && major != *majorVersion {
+			continue
+		}
+
+		if latest == "" {
+			latest = version
+			continue
+		}
+
+		if result, err := CompareVersions(version, latest); err == nil && result > 0 {
+			latest = version
+		}
+	}
+
+	return latest
+}
+
+// FindLatestVersionWithPrereleases behaves like FindLatestVersion but also considers
+// pre-release versions, preferring a stable release when the version cores are equal
+func FindLatestVersionWithPrereleases(versions []string, majorVersion *int) string {
+	latest := FindLatestVersion(versions, majorVersion)
+	for _, version := range versions {
+		if !IsPrereleaseVersion(version) {
+			continue
+		}
+
+		major, _, _, err := ParseVersion(version)
+		if err != nil {
+			continue
+		}
+		if majorVersion != nil && major != *majorVersion {
+			continue
+		}
+
+		if latest == "" {
+			latest = version
+			continue
+		}
+
+		result, err := CompareVersions(version, latest)
+		if err != nil {
+			continue
+		}
+		if result > 0 || (result == 0 && IsPrereleaseVersion(latest) && comparePrereleaseIdentifiers(version, latest) > 0) {
+			latest = version
+		}
+	}
+
+	return latest
+}
+
+// prereleaseIdentifiers splits the pre-release part of a version such as "2.0.0-rc.10" or
+// "7.1.0.rc1" into runs of letters and digits
+func prereleaseIdentifiers(version string) []string {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+	if idx := strings.Index(version, "+"); idx != -1 {
+		version = version[:idx]
+	}
+	suffix := strings.TrimLeft(version, "0123456789.")
+
+	var identifiers []string
+	start := -1
+	for i, r := range suffix + "." {
+		isDigit := r >= '0' && r <= '9'
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if start != -1 {
+			startIsDigit := suffix[start] >= '0' && suffix[start] <= '9'
+			if (!isDigit && !isLetter) || isDigit != startIsDigit {
+				identifiers = append(identifiers, suffix[start:i])
+				start = -1
+			}
+		}
+		if start == -1 && (isDigit || isLetter) {
+			start = i
+		}
+	}
+	return identifiers
+}
+
+// comparePrereleaseIdentifiers compares the pre-release parts of two versions, comparing
+// numeric identifiers numerically so that rc10 sorts after rc2
+func comparePrereleaseIdentifiers(v1, v2 string) int {
+	ids1, ids2 := prereleaseIdentifiers(v1), prereleaseIdentifiers(v2)
+	for i := 0; i < len(ids1) && i < len(ids2); i++ {
+		n1, err1 := strconv.Atoi(ids1[i])
+		n2, err2 := strconv.Atoi(ids2[i])
+		switch {
+		case err1 == nil && err2 == nil:
+			if n1 != n2 {
+				if n1 < n2 {
+					return -1
+				}
+				return 1
+			}
+		case err1 == nil:
+			// Numeric identifiers sort before alphanumeric ones
+			return -1
+		case err2 == nil:
+			return 1
+		default:
+			if c := strings.Compare(ids1[i], ids2[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	switch {
+	case len(ids1) < len(ids2):
+		return -1
+	case len(ids1) > len(ids2):
+		return 1
+	}
+	return 0
+}
+
+// parseVersionConstraints parses the optional constraints argument shared by several tools
+func parseVersionConstraints(args map[string]interface{}) VersionConstraints {
+	constraints := make(VersionConstraints)
+	constraintsMap, ok := args["constraints"].(map[string]interface{})
+	if !ok {
+		return constraints
+	}
+
+	for name, constraintRaw := range constraintsMap {
+		if constraintMap, ok := constraintRaw.(map[string]interface{}); ok {
+			var constraint VersionConstraint
+			if majorVersion, ok := constraintMap["majorVersion"].(float64); ok {
+				majorInt := int(majorVersion)
+				constraint.MajorVersion = &majorInt
+			}
+			if excludePackage, ok := constraintMap["excludePackage"].(bool); ok {
+				constraint.ExcludePackage = excludePackage
+			}
+			constraints[name] = constraint
+		}
+	}
+
+	return constraints
+}
+
 // CleanVersion removes any leading version prefix (^, ~, >, =, <, etc.) from a version string
 func CleanVersion(version string) string {
 	re := regexp.MustCompile(`^[\^~>=<]+`)
 	return re.ReplaceAllString(version, "")
 }
 
+// cleanVersionRequirement extracts a comparable version from a version requirement that
+// may contain several comma separated clauses (e.g. ">= 1.2, < 2" becomes "1.2")
+func cleanVersionRequirement(requirement string) string {
+	if idx := strings.Index(requirement, ","); idx != -1 {
+		requirement = requirement[:idx]
+	}
+	return strings.TrimSpace(CleanVersion(strings.TrimSpace(requirement)))
+}
+
 // StringPtr returns a pointer to the given string
 func StringPtr(s string) *string {
 	return &s
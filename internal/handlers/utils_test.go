@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPrereleaseVersion(t *testing.T) {
+	tests := map[string]bool{
+		"1.2.3":         false,
+		"v1.2.3":        false,
+		"1.2.3+build.5": false,
+		"1.0.0-beta.1":  true,
+		"7.1.0.rc1":     true,
+		"2.0.0.beta":    true,
+	}
+
+	for version, want := range tests {
+		assert.Equal(t, want, IsPrereleaseVersion(version), version)
+	}
+}
+
+func TestFindLatestVersion(t *testing.T) {
+	versions := []string{"1.9.0", "1.10.0", "2.0.0.rc1", "2.0.0-beta.2", "0.9.9"}
+
+	assert.Equal(t, "1.10.0", FindLatestVersion(versions, nil))
+	assert.Equal(t, "0.9.9", FindLatestVersion(versions, IntPtr(0)))
+	assert.Equal(t, "", FindLatestVersion(versions, IntPtr(3)))
+
+	assert.Equal(t, "2.0.0.rc1", FindLatestVersionWithPrereleases(versions, nil))
+	assert.Equal(t, "2.0.0", FindLatestVersionWithPrereleases(append(versions, "2.0.0"), nil))
+
+	// Numeric pre-release identifiers are compared numerically
+	assert.Equal(t, "2.0.0.rc10", FindLatestVersionWithPrereleases([]string{"2.0.0.rc2", "2.0.0.rc10", "2.0.0.beta3"}, nil))
+	assert.Equal(t, "3.0.0-rc.10", FindLatestVersionWithPrereleases([]string{"3.0.0-rc.10", "3.0.0-rc.9", "3.0.0-rc.1"}, nil))
+	assert.Equal(t, "3.0.0-beta", FindLatestVersionWithPrereleases([]string{"3.0.0-alpha.2", "3.0.0-beta"}, nil))
+}
+
+func TestMakeRequestRateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/primary":
+			w.Header().Set("X-RateLimit-Limit", "60")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1767225600")
+			w.WriteHeader(http.StatusForbidden)
+		case "/secondary":
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			// Ordinary permission errors aren't rate limits
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	_, _, err := makeRateLimitedRequest(server.Client(), nil, "GET", server.URL+"/primary", nil, nil)
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, http.StatusForbidden, rateLimitErr.StatusCode)
+	assert.Equal(t, 60, *rateLimitErr.Limit)
+	assert.Equal(t, 0, *rateLimitErr.Remaining)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *rateLimitErr.ResetAt)
+	assert.Contains(t, rateLimitErr.Error(), "0 of 60 requests remaining, resets at 2026-01-01T00:00:00Z")
+
+	_, _, err = makeRateLimitedRequest(server.Client(), nil, "GET", server.URL+"/secondary", nil, nil)
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, 30, *rateLimitErr.RetryAfter)
+	require.NotNil(t, rateLimitErr.ResetAt)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), *rateLimitErr.ResetAt, 5*time.Second)
+
+	_, _, err = makeRateLimitedRequest(server.Client(), nil, "GET", server.URL+"/forbidden", nil, nil)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &rateLimitErr))
+
+	// Registries other than forges report rate limits as ordinary errors
+	_, err = MakeRequest(server.Client(), "GET", server.URL+"/primary", nil)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &rateLimitErr))
+}
@@ -140,6 +140,16 @@ func (s *PackageVersionServer) Initialize(srv *mcpserver.MCPServer) error {
 	s.registerDockerTool(srv)
 	s.registerSwiftTool(srv)
 	s.registerGitHubActionsTool(srv)
+	s.registerCargoTool(srv)
+	s.registerRubyTool(srv)
+	s.registerNuGetTool(srv)
+	s.registerPHPTool(srv)
+	s.registerHexTool(srv)
+	s.registerDartTool(srv)
+	s.registerTerraformTool(srv)
+	s.registerHelmTool(srv)
+	s.registerDockerfileTool(srv)
+	s.registerContainerImagesTool(srv)
 
 	// Register empty resource and prompt handlers to handle resources/list and prompts/list requests
 	s.registerEmptyResourceHandlers(srv)
@@ -490,18 +500,17 @@ func (s *PackageVersionServer) registerDockerTool(srv *mcpserver.MCPServer) {
 	dockerHandler := handlers.NewDockerHandler(s.logger, s.sharedCache)
 
 	dockerTool := mcp.NewTool("check_docker_tags",
-		mcp.WithDescription("Get the latest, up to date tags for Docker container images from Docker Hub, GitHub Container Registry, or custom registries for use when writing Dockerfiles or docker-compose files"),
+		mcp.WithDescription("Get the latest, up to date tags for Docker container images from Docker Hub, GitHub Container Registry, Quay.io, Amazon ECR Public, Microsoft Artifact Registry, Google Artifact Registry, or custom registries for use when writing Dockerfiles or docker-compose files"),
 		mcp.WithString("image",
 			mcp.Required(),
-			mcp.Description("Required: Docker image name (e.g., \"nginx\", \"ubuntu\", \"ghcr.io/owner/repo\")"),
+			mcp.Description("Required: Docker image name (e.g., \"nginx\", \"ubuntu\", \"ghcr.io/owner/repo\", \"ghcr.io/owner/group/image\")"),
 		),
 		mcp.WithString("registry",
-			mcp.Description("Registry to check (dockerhub, ghcr, or custom)"),
-			mcp.Enum("dockerhub", "ghcr", "custom"),
-			mcp.DefaultString("dockerhub"),
+			mcp.Description("Registry to check (dockerhub, ghcr, quay, ecr-public, mcr, gar, or custom). Detected from fully qualified image references such as \"quay.io/prometheus/node-exporter\" when omitted, otherwise dockerhub"),
+			mcp.Enum("dockerhub", "ghcr", "quay", "ecr-public", "mcr", "gar", "custom"),
 		),
 		mcp.WithString("customRegistry",
-			mcp.Description("URL for custom registry (required when registry is \"custom\")"),
+			mcp.Description("Host or URL of an OCI Distribution compatible registry such as Harbor or Artifactory (required when registry is \"custom\"). Credentials are read from the Docker config file"),
 		),
 		mcp.WithNumber("limit",
 			mcp.Description("Maximum number of tags to return"),
@@ -527,6 +536,24 @@ func (s *PackageVersionServer) registerDockerTool(srv *mcpserver.MCPServer) {
 			mcp.Description("Include image digest in results"),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithString("sort",
+			mcp.Description("Order tags by version (semver) or push time (updated), returning the newest tag of each variant such as -alpine or -bookworm. Tags that aren't versions are omitted. Defaults to registry order"),
+			mcp.Enum("semver", "updated"),
+		),
+		mcp.WithNumber("maxPages",
+			mcp.Description("Maximum number of Docker Hub tag pages (100 tags each) to fetch"),
+			mcp.DefaultNumber(10),
+		),
+		mcp.WithBoolean("platforms",
+			mcp.Description("Include each platform's os, architecture, variant, digest and size, and the digest of the multi-arch image index"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("platform",
+			mcp.Description("Only return tags that support this platform (e.g., \"linux/arm64\", \"linux/arm/v7\")"),
+		),
+		mcp.WithString("currentTag",
+			mcp.Description("Tag currently in use (e.g., \"3.11-slim-bookworm\"). When set, returns the newest tag with the same variant and precision compared with the current tag instead of a tag list"),
+		),
 	)
 
 	// Add Docker handler
@@ -549,12 +576,15 @@ func (s *PackageVersionServer) registerSwiftTool(srv *mcpserver.MCPServer) {
 		mcp.WithDescription("Check latest stable versions for Swift packages in Package.swift"),
 		mcp.WithArray("dependencies",
 			mcp.Required(),
-			mcp.Description("Required: Array of Swift package dependencies"),
+			mcp.Description("Required: Array of Swift package dependencies, each with a repository url on GitHub, GitLab, Gitea/Forgejo or a self-hosted forge configured in GIT_FORGES, or a Swift Package Registry identity given as id (e.g. \"mona.LinkedList\"), and optionally the current version and Package.swift requirement (e.g. from: \"1.0.0\", .upToNextMinor(from: \"1.2.0\"), exact: \"1.2.3\", \"1.0.0\"..<\"2.0.0\" or branch: \"main\")"),
 			mcp.Items(map[string]interface{}{"type": "object"}),
 		),
 		mcp.WithObject("constraints",
 			mcp.Description("Optional constraints for specific packages"),
 		),
+		mcp.WithString("registry",
+			mcp.Description("Swift Package Registry URL for package ids (e.g., \"https://packages.example.com/swift\"), defaulting to SWIFT_REGISTRY_URL. SWIFT_REGISTRY_TOKEN is only sent to SWIFT_REGISTRY_URL"),
+		),
 	)
 
 	// Add Swift handler
@@ -572,14 +602,35 @@ func (s *PackageVersionServer) registerGitHubActionsTool(srv *mcpserver.MCPServe
 	githubActionsTool := mcp.NewTool("check_github_actions",
 		mcp.WithDescription("Get the current, up to date GitHub Actions versions to use when adding or updating GitHub Actions"),
 		mcp.WithArray("actions",
-			mcp.Required(),
-			mcp.Description("Required: Array of GitHub Actions to check"),
+			mcp.Description("Array of GitHub Actions to check, each with an owner, repo and optional currentVersion (required unless workflow or uses is given)"),
 			mcp.Items(map[string]interface{}{"type": "object"}),
 		),
+		mcp.WithString("workflow",
+			mcp.Description("Raw workflow or action.yml YAML. Every job-level and step-level uses: reference is checked and results are keyed by the original reference"),
+		),
+		mcp.WithArray("uses",
+			mcp.Description("Array of uses: references to check (e.g., [\"actions/checkout@v4\", \"github/codeql-action/init@v3\"]). Results are keyed by the original reference"),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
 		mcp.WithBoolean("includeDetails",
 			mcp.Description("Include additional details like published date and URL"),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithBoolean("pinSHA",
+			mcp.Description("Include the commit SHA of the latest version and a ready-to-paste \"uses: owner/repo@<sha> # vX.Y.Z\" line. Current versions given as commit SHAs are always verified against the repository's tags"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("host",
+			mcp.Description("Forge host to resolve owner/repo references against, such as a GitHub Enterprise Server, Gitea or Forgejo host configured in GIT_FORGES (default: github.com). Full URL references like https://code.forgejo.org/actions/checkout@v4 always use their own host"),
+		),
+		mcp.WithBoolean("includeTags",
+			mcp.Description("List each action's tags to report the newest exact and floating major tags and whether the current version is behind a newer major version. Always reported when the tags were already loaded by a batched GraphQL query"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("checkRuntime",
+			mcp.Description("Read action.yml at the current and latest versions to report runs.using (e.g. node20, docker, composite), flag deprecated Node runtimes and list the actions used by composite actions"),
+			mcp.DefaultBool(false),
+		),
 	)
 
 	// Add GitHub Actions handler
@@ -588,3 +639,253 @@ func (s *PackageVersionServer) registerGitHubActionsTool(srv *mcpserver.MCPServe
 		return githubActionsHandler.GetLatestVersion(ctx, request.Params.Arguments)
 	})
 }
+
+// registerCargoTool registers the Cargo version checking tool
+func (s *PackageVersionServer) registerCargoTool(srv *mcpserver.MCPServer) {
+	// Create Cargo handler with a logger that doesn't output to stdout/stderr in stdio mode
+	cargoHandler := handlers.NewCargoHandler(s.logger, s.sharedCache)
+
+	cargoTool := mcp.NewTool("check_cargo_versions",
+		mcp.WithDescription("Get the current, up to date Rust crate versions from crates.io to use when adding or updating dependencies in Cargo.toml"),
+		mcp.WithObject("dependencies",
+			mcp.Required(),
+			mcp.Description("Required: Dependencies from Cargo.toml, either a single table or an object with \"dependencies\", \"dev-dependencies\" and \"build-dependencies\" tables (e.g., { \"dependencies\": { \"serde\": { \"version\": \"1.0\", \"features\": [\"derive\"] }, \"tokio\": \"1\" } })"),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific crates"),
+		),
+	)
+
+	// Add Cargo handler
+	srv.AddTool(cargoTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_cargo_versions").Debug("Received request")
+		return cargoHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerRubyTool registers the Ruby version checking tool
+func (s *PackageVersionServer) registerRubyTool(srv *mcpserver.MCPServer) {
+	// Create Ruby handler with a logger that doesn't output to stdout/stderr in stdio mode
+	rubyHandler := handlers.NewRubyHandler(s.logger, s.sharedCache)
+
+	rubyTool := mcp.NewTool("check_ruby_versions",
+		mcp.WithDescription("Get the current, up to date Ruby gem versions from RubyGems to use when adding or updating gems in a Gemfile"),
+		mcp.WithArray("gems",
+			mcp.Required(),
+			mcp.Description("Required: Array of gems from the Gemfile, each with a name, optional requirement and optional group (e.g., [{ \"name\": \"rails\", \"requirement\": \"~> 7.1\" }, { \"name\": \"rspec-rails\", \"group\": \"test\" }])"),
+			mcp.Items(map[string]interface{}{"type": "object"}),
+		),
+		mcp.WithBoolean("includePrerelease",
+			mcp.Description("Include pre-release versions when determining the latest version"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific gems"),
+		),
+	)
+
+	// Add Ruby handler
+	srv.AddTool(rubyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_ruby_versions").Debug("Received request")
+		return rubyHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerNuGetTool registers the NuGet version checking tool
+func (s *PackageVersionServer) registerNuGetTool(srv *mcpserver.MCPServer) {
+	// Create NuGet handler with a logger that doesn't output to stdout/stderr in stdio mode
+	nugetHandler := handlers.NewNuGetHandler(s.logger, s.sharedCache)
+
+	nugetTool := mcp.NewTool("check_nuget_versions",
+		mcp.WithDescription("Get the current, up to date NuGet package versions to use when adding or updating PackageReference entries in .csproj or Directory.Packages.props files"),
+		mcp.WithArray("packages",
+			mcp.Required(),
+			mcp.Description("Required: Array of package references, each with an id, optional version and optional targetFramework (e.g., [{ \"id\": \"Newtonsoft.Json\", \"version\": \"13.0.1\" }])"),
+			mcp.Items(map[string]interface{}{"type": "object"}),
+		),
+		mcp.WithBoolean("includePrerelease",
+			mcp.Description("Include pre-release versions when determining the latest version"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific packages"),
+		),
+	)
+
+	// Add NuGet handler
+	srv.AddTool(nugetTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_nuget_versions").Debug("Received request")
+		return nugetHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerPHPTool registers the PHP Composer version checking tool
+func (s *PackageVersionServer) registerPHPTool(srv *mcpserver.MCPServer) {
+	// Create PHP handler with a logger that doesn't output to stdout/stderr in stdio mode
+	phpHandler := handlers.NewPHPHandler(s.logger, s.sharedCache)
+
+	phpTool := mcp.NewTool("check_composer_versions",
+		mcp.WithDescription("Get the current, up to date PHP package versions from Packagist to use when adding or updating dependencies in composer.json"),
+		mcp.WithObject("dependencies",
+			mcp.Required(),
+			mcp.Description("Required: Dependencies from composer.json, either a single require object or an object with \"require\" and \"require-dev\" (e.g., { \"require\": { \"php\": \"^8.2\", \"symfony/console\": \"^6.4\" } })"),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific packages"),
+		),
+	)
+
+	// Add PHP handler
+	srv.AddTool(phpTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_composer_versions").Debug("Received request")
+		return phpHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerHexTool registers the Hex version checking tool
+func (s *PackageVersionServer) registerHexTool(srv *mcpserver.MCPServer) {
+	// Create Hex handler with a logger that doesn't output to stdout/stderr in stdio mode
+	hexHandler := handlers.NewHexHandler(s.logger, s.sharedCache)
+
+	hexTool := mcp.NewTool("check_hex_versions",
+		mcp.WithDescription("Get the current, up to date Elixir/Erlang package versions from hex.pm to use when adding or updating dependencies in mix.exs"),
+		mcp.WithArray("dependencies",
+			mcp.Required(),
+			mcp.Description("Required: Array of mix.exs dependencies, either as tuple strings (e.g., \"{:phoenix, \\\"~> 1.7\\\"}\") or objects with name, requirement and options (e.g., { \"name\": \"ex_doc\", \"requirement\": \"~> 0.31\", \"options\": { \"only\": \"dev\" } })"),
+			mcp.Items(map[string]interface{}{
+				"anyOf": []map[string]interface{}{{"type": "string"}, {"type": "object"}},
+			}),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific packages"),
+		),
+	)
+
+	// Add Hex handler
+	srv.AddTool(hexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_hex_versions").Debug("Received request")
+		return hexHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerDartTool registers the Dart/Flutter version checking tool
+func (s *PackageVersionServer) registerDartTool(srv *mcpserver.MCPServer) {
+	// Create Dart handler with a logger that doesn't output to stdout/stderr in stdio mode
+	dartHandler := handlers.NewDartHandler(s.logger, s.sharedCache)
+
+	dartTool := mcp.NewTool("check_pub_versions",
+		mcp.WithDescription("Get the current, up to date Dart and Flutter package versions from pub.dev to use when adding or updating dependencies in pubspec.yaml"),
+		mcp.WithObject("dependencies",
+			mcp.Required(),
+			mcp.Description("Required: Dependencies from pubspec.yaml, either a single map or an object with \"dependencies\" and \"dev_dependencies\" maps (e.g., { \"dependencies\": { \"http\": \"^1.1.0\", \"flutter\": { \"sdk\": \"flutter\" } } })"),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific packages"),
+		),
+	)
+
+	// Add Dart handler
+	srv.AddTool(dartTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_pub_versions").Debug("Received request")
+		return dartHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerTerraformTool registers the Terraform version checking tool
+func (s *PackageVersionServer) registerTerraformTool(srv *mcpserver.MCPServer) {
+	// Create Terraform handler with a logger that doesn't output to stdout/stderr in stdio mode
+	terraformHandler := handlers.NewTerraformHandler(s.logger, s.sharedCache)
+
+	terraformTool := mcp.NewTool("check_terraform_versions",
+		mcp.WithDescription("Get the current, up to date Terraform/OpenTofu provider and module versions to use when writing required_providers blocks or module sources"),
+		mcp.WithArray("providers",
+			mcp.Description("Array of required_providers entries, each with a source, optional version constraint and optional local name (e.g., [{ \"name\": \"aws\", \"source\": \"hashicorp/aws\", \"version\": \"~> 5.0\" }])"),
+			mcp.Items(map[string]interface{}{"type": "object"}),
+		),
+		mcp.WithArray("modules",
+			mcp.Description("Array of registry modules, each with a source (namespace/name/provider), optional version constraint and optional name (e.g., [{ \"source\": \"terraform-aws-modules/vpc/aws\", \"version\": \"~> 5.0\" }])"),
+			mcp.Items(map[string]interface{}{"type": "object"}),
+		),
+		mcp.WithString("registry",
+			mcp.Description("Registry host for sources without a hostname (e.g., \"registry.opentofu.org\" or a private registry)"),
+			mcp.DefaultString(handlers.TerraformRegistryHost),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific providers or modules"),
+		),
+	)
+
+	// Add Terraform handler
+	srv.AddTool(terraformTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_terraform_versions").Debug("Received request")
+		return terraformHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerHelmTool registers the Helm chart version checking tool
+func (s *PackageVersionServer) registerHelmTool(srv *mcpserver.MCPServer) {
+	// Create Helm handler with a logger that doesn't output to stdout/stderr in stdio mode
+	helmHandler := handlers.NewHelmHandler(s.logger, s.sharedCache)
+
+	helmTool := mcp.NewTool("check_helm_charts",
+		mcp.WithDescription("Get the current, up to date Helm chart versions and their appVersion to use when adding or updating dependencies in Chart.yaml"),
+		mcp.WithArray("dependencies",
+			mcp.Required(),
+			mcp.Description("Required: Array of Chart.yaml dependencies, each with a name, version and repository (http(s):// chart repository or oci:// registry) (e.g., [{ \"name\": \"postgresql\", \"version\": \"12.x.x\", \"repository\": \"https://charts.bitnami.com/bitnami\" }])"),
+			mcp.Items(map[string]interface{}{"type": "object"}),
+		),
+		mcp.WithObject("constraints",
+			mcp.Description("Optional constraints for specific charts"),
+		),
+	)
+
+	// Add Helm handler
+	srv.AddTool(helmTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_helm_charts").Debug("Received request")
+		return helmHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerDockerfileTool registers the Dockerfile base image checking tool
+func (s *PackageVersionServer) registerDockerfileTool(srv *mcpserver.MCPServer) {
+	// Create Dockerfile handler with a logger that doesn't output to stdout/stderr in stdio mode
+	dockerfileHandler := handlers.NewDockerfileHandler(s.logger, s.sharedCache)
+
+	dockerfileTool := mcp.NewTool("check_dockerfile",
+		mcp.WithDescription("Check every FROM instruction in a Dockerfile and get the newest tag of each base image with the same variant and precision, for use when updating Dockerfiles"),
+		mcp.WithString("dockerfile",
+			mcp.Required(),
+			mcp.Description("Required: Contents of the Dockerfile"),
+		),
+		mcp.WithObject("buildArgs",
+			mcp.Description("Optional build arguments overriding ARG defaults used in FROM instructions (e.g., { \"PYTHON_VERSION\": \"3.12\" })"),
+		),
+	)
+
+	// Add Dockerfile handler
+	srv.AddTool(dockerfileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_dockerfile").Debug("Received request")
+		return dockerfileHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}
+
+// registerContainerImagesTool registers the compose file and Kubernetes manifest image checking tool
+func (s *PackageVersionServer) registerContainerImagesTool(srv *mcpserver.MCPServer) {
+	// Create container images handler with a logger that doesn't output to stdout/stderr in stdio mode
+	containerImagesHandler := handlers.NewContainerImagesHandler(s.logger, s.sharedCache)
+
+	containerImagesTool := mcp.NewTool("check_container_images",
+		mcp.WithDescription("Check the image references in docker-compose files and Kubernetes manifests (including multi-document and Helm-rendered YAML) and get the newest tag of each image with the same variant and precision"),
+		mcp.WithArray("files",
+			mcp.Required(),
+			mcp.Description("Required: Array of YAML files, each with a path and content (e.g., [{ \"path\": \"docker-compose.yml\", \"content\": \"services:\\n  web:\\n    image: nginx:1.25\" }])"),
+			mcp.Items(map[string]interface{}{"type": "object"}),
+		),
+	)
+
+	// Add container images handler
+	srv.AddTool(containerImagesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.logger.WithField("tool", "check_container_images").Debug("Received request")
+		return containerImagesHandler.GetLatestVersion(ctx, request.Params.Arguments)
+	})
+}